@@ -2,10 +2,15 @@ package cmd
 
 import (
 	goerrors "errors"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/bauersimon/grnkdb/converter"
+	"github.com/bauersimon/grnkdb/metadata"
 	"github.com/bauersimon/grnkdb/model"
 	"github.com/bauersimon/grnkdb/steam"
 	"github.com/jessevdk/go-flags"
@@ -16,9 +21,17 @@ import (
 type ConvertCommand struct {
 	logger *zap.Logger
 
-	Input      string `long:"input" default:"./data" description:"Input directory containing CSV files"`
-	Output     string `long:"output" default:"./public/data.json" description:"Output JSON file path"`
-	WindowSize uint   `long:"window-size" default:"100" description:"Conversion window size"`
+	Input       string `long:"input" default:"./data" description:"Input directory containing CSV files"`
+	Output      string `long:"output" default:"./public/data.json" description:"Output JSON file path"`
+	Explain     string `long:"explain" description:"Explanation report file path, Markdown for \".md\" files and JSON otherwise"`
+	Workers     uint   `long:"workers" default:"8" description:"Number of concurrent conversion workers"`
+	Rules       string `long:"rules" description:"Title cleanup rules JSON file, uses the built-in rules if empty"`
+	Shorts      string `long:"shorts" default:"include" choice:"include" choice:"exclude" choice:"known" description:"Conversion of YouTube Shorts and clips, either like all other videos, not at all or only for games known from other videos"`
+	MergePolicy string `long:"merge-policy" default:"earliest" choice:"earliest" choice:"prefer-new" choice:"prefer-existing" description:"Resolution of conflicts between converted and existing games, either the earliest content start, the converted or the existing values"`
+	MergeReport string `long:"merge-report" description:"Merge conflict report JSON file path"`
+	Tombstones  string `long:"tombstones" default:"./data/tombstones.json" description:"Tombstone JSON file of deleted game IDs or names which are dropped from the output, ignored if it does not exist"`
+	Rebuild     bool   `long:"rebuild" description:"Regenerate the output from scratch and ignore the existing output file"`
+	DryRun      bool   `long:"dry-run" description:"Convert without writing the output file, e.g. to try out rules together with an explanation report"`
 }
 
 func NewConvertCommand(logger *zap.Logger) flags.Commander {
@@ -28,12 +41,47 @@ func NewConvertCommand(logger *zap.Logger) flags.Commander {
 }
 
 func (cmd *ConvertCommand) Execute(args []string) error {
-	videoConverter := converter.NewVideoToGameConverter(steam.NewClient(), cmd.WindowSize, cmd.logger)
+	metadataProvider := metadata.Chain{
+		steam.NewClient(),
+		metadata.NewGOGProvider(),
+		metadata.NewEpicProvider(),
+		metadata.NewItchProvider(),
+		metadata.NewEShopProvider(),
+	}
+	rules, err := readRules(cmd.Rules)
+	if err != nil {
+		return err
+	}
+	videoConverter := converter.NewVideoToGameConverter(metadataProvider, rules, converter.ShortsPolicy(cmd.Shorts), cmd.Workers, cmd.logger)
+
+	return cmd.convertCSVToGames(videoConverter, cmd.Input, cmd.Output, cmd.Explain)
+}
+
+// readRules reads the title cleanup rules from a file, or returns the default rules if the path is empty.
+func readRules(path string) (rules *converter.Rules, err error) {
+	if path == "" {
+		return converter.DefaultRules(), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open rules file %s", path)
+	}
+	defer func() {
+		if errClose := file.Close(); errClose != nil {
+			err = goerrors.Join(err, errors.WithStack(errClose))
+		}
+	}()
+
+	rules, err = converter.ReadRules(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read rules file %s", path)
+	}
 
-	return cmd.convertCSVToGames(videoConverter, cmd.Input, cmd.Output)
+	return rules, nil
 }
 
-func (cmd *ConvertCommand) convertCSVToGames(converter converter.Interface, inputDir, outputPath string) (err error) {
+func (cmd *ConvertCommand) convertCSVToGames(videoConverter converter.Interface, inputDir, outputPath, explainPath string) (err error) {
 	csvFiles, err := filepath.Glob(filepath.Join(inputDir, "*.csv"))
 	if err != nil {
 		return errors.WithStack(err)
@@ -59,15 +107,20 @@ func (cmd *ConvertCommand) convertCSVToGames(converter converter.Interface, inpu
 			}
 		}()
 
-		videos, err := model.VideoCSVRead(file)
-		if err != nil {
+		count := len(allVideos)
+		videoReader := model.NewVideoCSVReader(file)
+		allVideos = slices.AppendSeq(allVideos, videoReader.Videos())
+		if err := videoReader.Err(); err != nil {
 			return errors.Wrapf(err, "failed to read CSV file %s", csvFile)
 		}
+		// Sort videos by VideoID for consistent output
+		slices.SortStableFunc(allVideos[count:], func(a, b *model.Video) int {
+			return strings.Compare(a.VideoID, b.VideoID)
+		})
 
-		allVideos = append(allVideos, videos...)
 		cmd.logger.Debug("loaded videos",
 			zap.String("file", filepath.Base(csvFile)),
-			zap.Int("count", len(videos)))
+			zap.Int("count", len(allVideos)-count))
 	}
 
 	if len(allVideos) == 0 {
@@ -77,36 +130,74 @@ func (cmd *ConvertCommand) convertCSVToGames(converter converter.Interface, inpu
 	}
 
 	cmd.logger.Info("converting videos to games", zap.Int("videos", len(allVideos)))
-	games, err := converter.Convert(allVideos)
+	games, err := videoConverter.Convert(allVideos)
 	if err != nil {
 		return err
 	}
 
 	cmd.logger.Info("conversion completed", zap.Int("games", len(games)))
 
+	if explainPath != "" {
+		if err := cmd.writeExplanations(videoConverter, explainPath); err != nil {
+			return err
+		}
+	}
+
+	if cmd.DryRun {
+		cmd.logger.Info("dry run, not writing the output", zap.String("output", outputPath), zap.Int("games", len(games)))
+
+		return nil
+	}
+
 	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
 		return errors.WithStack(err)
 	}
 
-	// Load existing data if it exists.
-	var existingData []*model.Game
-	if _, err := os.Stat(outputPath); err == nil {
+	sources := map[model.SourceType]bool{}
+	for _, video := range allVideos {
+		sources[video.Source] = true
+	}
+
+	tombstones, err := readTombstones(cmd.Tombstones)
+	if err != nil {
+		return err
+	} else if len(tombstones) > 0 {
+		cmd.logger.Info("loaded tombstones", zap.Int("count", len(tombstones)))
+	}
+
+	// Merge with existing data if it exists, unless the output is rebuilt from scratch.
+	var merged bool
+	var conflicts []*model.MergeConflict
+	if cmd.Rebuild {
+		cmd.logger.Info("rebuilding data and ignoring existing data", zap.String("file", outputPath))
+	} else if _, err := os.Stat(outputPath); err == nil {
 		cmd.logger.Info("loading existing data", zap.String("file", outputPath))
-		readFile, err := os.Open(outputPath)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		existingData, err = model.JSONRead(readFile)
-		closeErr := readFile.Close()
-		if err != nil || closeErr != nil {
-			return goerrors.Join(errors.WithStack(err), errors.WithStack(closeErr))
+		if games, conflicts, err = cmd.mergeExistingGames(games, outputPath, sources, tombstones); err != nil {
+			return err
 		}
-		cmd.logger.Info("loaded existing games", zap.Int("count", len(existingData)))
+		merged = true
 	}
+	if !merged && len(tombstones) > 0 {
+		games, conflicts = model.MergeGamesWithPolicy(games, nil, model.MergePolicy(cmd.MergePolicy), tombstones)
+		merged = true
+	}
+	if merged {
+		for _, conflict := range conflicts {
+			cmd.logger.Info("resolved merge conflict",
+				zap.String("game", conflict.Game),
+				zap.String("field", conflict.Field),
+				zap.String("content", conflict.Content),
+				zap.String("new", conflict.New),
+				zap.String("existing", conflict.Existing),
+				zap.String("resolved", conflict.Resolved))
+		}
+		cmd.logger.Info("merged with existing data", zap.Int("games", len(games)), zap.Int("conflicts", len(conflicts)))
 
-	if existingData != nil {
-		games = model.MergeGames(games, existingData)
-		cmd.logger.Info("merged with existing data", zap.Int("games", len(games)))
+		if cmd.MergeReport != "" {
+			if err := writeMergeReport(cmd.MergeReport, conflicts); err != nil {
+				return err
+			}
+		}
 	}
 
 	file, err := os.Create(outputPath)
@@ -117,7 +208,11 @@ func (cmd *ConvertCommand) convertCSVToGames(converter converter.Interface, inpu
 		err = goerrors.Join(file.Close(), err)
 	}()
 
-	if err := model.JSONWrite(file, games); err != nil {
+	if err := model.JSONWrite(file, &model.Data{
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Sources:     slices.Collect(maps.Keys(sources)),
+		Games:       games,
+	}); err != nil {
 		return err
 	}
 
@@ -126,3 +221,115 @@ func (cmd *ConvertCommand) convertCSVToGames(converter converter.Interface, inpu
 		zap.Int("games", len(games)))
 	return nil
 }
+
+// mergeExistingGames merges the games with the existing games of a data file and adds the sources of the data file.
+// The existing games are merged one at a time while reading them, so they are not held twice.
+func (cmd *ConvertCommand) mergeExistingGames(games []*model.Game, path string, sources map[model.SourceType]bool, tombstones []*model.Tombstone) (merged []*model.Game, conflicts []*model.MergeConflict, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	defer func() {
+		err = goerrors.Join(err, errors.WithStack(file.Close()))
+	}()
+
+	gameReader, err := model.NewJSONReader(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, source := range gameReader.Data().Sources {
+		sources[source] = true
+	}
+
+	existingGames := 0
+	merged, conflicts = model.MergeGamesSeq(games, func(yield func(*model.Game) bool) {
+		for game := range gameReader.Games() {
+			existingGames++
+			if !yield(game) {
+				return
+			}
+		}
+	}, model.MergePolicy(cmd.MergePolicy), tombstones)
+	if err := gameReader.Err(); err != nil {
+		return nil, nil, err
+	}
+	cmd.logger.Info("loaded existing games", zap.Int("count", existingGames))
+
+	return merged, conflicts, nil
+}
+
+// readTombstones reads the tombstones from a file, or returns no tombstones if the path is empty or the file does not exist.
+func readTombstones(path string) (tombstones []*model.Tombstone, err error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to open tombstones file %s", path)
+	}
+	defer func() {
+		if errClose := file.Close(); errClose != nil {
+			err = goerrors.Join(err, errors.WithStack(errClose))
+		}
+	}()
+
+	tombstones, err = model.TombstonesJSONRead(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read tombstones file %s", path)
+	}
+
+	return tombstones, nil
+}
+
+// writeMergeReport writes the resolved merge conflicts to a JSON file.
+func writeMergeReport(path string, conflicts []*model.MergeConflict) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		err = goerrors.Join(err, errors.WithStack(file.Close()))
+	}()
+
+	return model.MergeConflictsJSONWrite(file, conflicts)
+}
+
+func (cmd *ConvertCommand) writeExplanations(videoConverter converter.Interface, explainPath string) (err error) {
+	explainer, ok := videoConverter.(converter.Explainer)
+	if !ok {
+		return errors.Errorf("converter %T cannot explain its conversion", videoConverter)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(explainPath), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	file, err := os.Create(explainPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		err = goerrors.Join(err, errors.WithStack(file.Close()))
+	}()
+
+	explanations := explainer.Explanations()
+	if strings.EqualFold(filepath.Ext(explainPath), ".md") {
+		err = converter.ExplanationsMarkdownWrite(file, explanations)
+	} else {
+		err = converter.ExplanationsJSONWrite(file, explanations)
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd.logger.Info("wrote explanations",
+		zap.String("file", explainPath),
+		zap.Int("videos", len(explanations)))
+
+	return nil
+}
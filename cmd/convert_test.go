@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bauersimon/grnkdb/converter"
+	"github.com/bauersimon/grnkdb/metadata"
 	mockConverter "github.com/bauersimon/grnkdb/mocks/github.com/bauersimon/grnkdb/converter"
 	"github.com/bauersimon/grnkdb/model"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,8 @@ func TestConvertCSVToGames(t *testing.T) {
 
 		Setup        func(t *testing.T, converter *mockConverter.MockInterface)
 		PrepareFiles func(t *testing.T, dir string)
+		Tombstones   string
+		Rebuild      bool
 
 		ExpectedGames []*model.Game
 		Error         string
@@ -40,8 +44,16 @@ func TestConvertCSVToGames(t *testing.T) {
 				tc.Setup(t, mockConverter)
 			}
 
-			cmd := &ConvertCommand{logger: zaptest.NewLogger(t)}
-			err := cmd.convertCSVToGames(mockConverter, tmpDir, outputPath)
+			cmd := &ConvertCommand{
+				logger: zaptest.NewLogger(t),
+
+				Rebuild: tc.Rebuild,
+			}
+			if tc.Tombstones != "" {
+				cmd.Tombstones = filepath.Join(tmpDir, "tombstones.json")
+				require.NoError(t, os.WriteFile(cmd.Tombstones, []byte(tc.Tombstones), 0644))
+			}
+			err := cmd.convertCSVToGames(mockConverter, tmpDir, outputPath, "")
 
 			if tc.Error != "" {
 				assert.ErrorContains(t, err, tc.Error)
@@ -59,11 +71,13 @@ func TestConvertCSVToGames(t *testing.T) {
 					require.NoError(t, err)
 					defer func() { require.NoError(t, file.Close()) }()
 
-					var actualGames []*model.Game
-					err = json.NewDecoder(file).Decode(&actualGames)
+					var actual model.Data
+					err = json.NewDecoder(file).Decode(&actual)
 					require.NoError(t, err)
 
-					assert.Equal(t, tc.ExpectedGames, actualGames)
+					assert.Equal(t, model.DataVersion, actual.Version)
+					assert.Equal(t, []model.SourceType{model.SourceYouTube}, actual.Sources)
+					assert.Equal(t, tc.ExpectedGames, actual.Games)
 				}
 			}
 		})
@@ -218,7 +232,7 @@ https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test de
 			file, err := os.Create(outputPath)
 			require.NoError(t, err)
 			defer func() { require.NoError(t, file.Close()) }()
-			err = model.JSONWrite(file, existingGames)
+			err = model.JSONWrite(file, &model.Data{Games: existingGames})
 			require.NoError(t, err)
 		},
 		Setup: func(t *testing.T, converter *mockConverter.MockInterface) {
@@ -239,6 +253,7 @@ https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test de
 		},
 		ExpectedGames: []*model.Game{
 			{
+				ID:   "existing-game",
 				Name: "Existing Game",
 				Content: []*model.Content{
 					{
@@ -260,4 +275,300 @@ https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test de
 			},
 		},
 	})
+
+	validate(t, &testCase{
+		Name: "Tombstones",
+		PrepareFiles: func(t *testing.T, dir string) {
+			csvContent := `Link,PublishedAt,Title,Description,ChannelID,VideoID,Source
+https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test description,UCTEST123,video1,youtube`
+
+			err := os.WriteFile(filepath.Join(dir, "UCTEST123.csv"), []byte(csvContent), 0644)
+			require.NoError(t, err)
+
+			file, err := os.Create(filepath.Join(dir, "output.json"))
+			require.NoError(t, err)
+			defer func() { require.NoError(t, file.Close()) }()
+			require.NoError(t, model.JSONWrite(file, &model.Data{Games: []*model.Game{
+				{
+					ID:   "im-anmarsch",
+					Name: "im Anmarsch",
+					Content: []*model.Content{
+						{
+							Link:   "https://www.youtube.com/watch?v=existing",
+							Start:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+							Source: model.SourceYouTube,
+						},
+					},
+				},
+			}}))
+		},
+		Setup: func(t *testing.T, converter *mockConverter.MockInterface) {
+			converter.EXPECT().Convert(mock.AnythingOfType("[]*model.Video")).Return([]*model.Game{
+				{
+					ID:   "new-game",
+					Name: "New Game",
+					Content: []*model.Content{
+						{
+							Link:   "https://www.youtube.com/watch?v=video1",
+							Start:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+							Source: model.SourceYouTube,
+						},
+					},
+				},
+				{
+					ID:   "um-mitternacht",
+					Name: "um Mitternacht",
+					Content: []*model.Content{
+						{
+							Link:   "https://www.youtube.com/watch?v=video2",
+							Start:  time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+							Source: model.SourceYouTube,
+						},
+					},
+				},
+			}, nil)
+		},
+		Tombstones: `[{"ID": "im-anmarsch"}, {"Name": "UM MITTERNACHT", "Reason": "Episode title"}]`,
+		ExpectedGames: []*model.Game{
+			{
+				ID:   "new-game",
+				Name: "New Game",
+				Content: []*model.Content{
+					{
+						Link:   "https://www.youtube.com/watch?v=video1",
+						Start:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						Source: model.SourceYouTube,
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Rebuild",
+		PrepareFiles: func(t *testing.T, dir string) {
+			csvContent := `Link,PublishedAt,Title,Description,ChannelID,VideoID,Source
+https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test description,UCTEST123,video1,youtube`
+
+			err := os.WriteFile(filepath.Join(dir, "UCTEST123.csv"), []byte(csvContent), 0644)
+			require.NoError(t, err)
+
+			file, err := os.Create(filepath.Join(dir, "output.json"))
+			require.NoError(t, err)
+			defer func() { require.NoError(t, file.Close()) }()
+			require.NoError(t, model.JSONWrite(file, &model.Data{Games: []*model.Game{
+				{
+					ID:   "im-anmarsch",
+					Name: "im Anmarsch",
+					Content: []*model.Content{
+						{
+							Link:   "https://www.youtube.com/watch?v=existing",
+							Start:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+							Source: model.SourceYouTube,
+						},
+					},
+				},
+			}}))
+		},
+		Setup: func(t *testing.T, converter *mockConverter.MockInterface) {
+			converter.EXPECT().Convert(mock.AnythingOfType("[]*model.Video")).Return([]*model.Game{
+				{
+					ID:   "new-game",
+					Name: "New Game",
+					Content: []*model.Content{
+						{
+							Link:   "https://www.youtube.com/watch?v=video1",
+							Start:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+							Source: model.SourceYouTube,
+						},
+					},
+				},
+				{
+					ID:   "um-mitternacht",
+					Name: "um Mitternacht",
+					Content: []*model.Content{
+						{
+							Link:   "https://www.youtube.com/watch?v=video2",
+							Start:  time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+							Source: model.SourceYouTube,
+						},
+					},
+				},
+			}, nil)
+		},
+		Rebuild: true,
+		ExpectedGames: []*model.Game{
+			{
+				ID:   "new-game",
+				Name: "New Game",
+				Content: []*model.Content{
+					{
+						Link:   "https://www.youtube.com/watch?v=video1",
+						Start:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						Source: model.SourceYouTube,
+					},
+				},
+			},
+			{
+				ID:   "um-mitternacht",
+				Name: "um Mitternacht",
+				Content: []*model.Content{
+					{
+						Link:   "https://www.youtube.com/watch?v=video2",
+						Start:  time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+						Source: model.SourceYouTube,
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Invalid tombstones",
+		PrepareFiles: func(t *testing.T, dir string) {
+			csvContent := `Link,PublishedAt,Title,Description,ChannelID,VideoID,Source
+https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test description,UCTEST123,video1,youtube`
+
+			err := os.WriteFile(filepath.Join(dir, "UCTEST123.csv"), []byte(csvContent), 0644)
+			require.NoError(t, err)
+		},
+		Setup: func(t *testing.T, converter *mockConverter.MockInterface) {
+			converter.EXPECT().Convert(mock.AnythingOfType("[]*model.Video")).Return(nil, nil)
+		},
+		Tombstones: `[{"Reason": "Nothing"}]`,
+		Error:      "neither an ID nor a name",
+	})
+}
+
+func TestConvertCSVToGamesExplain(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvContent := `Link,PublishedAt,Title,Description,ChannelID,VideoID,Source
+https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test description,UCTEST123,video1,youtube`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "UCTEST123.csv"), []byte(csvContent), 0644))
+
+	t.Run("JSON", func(t *testing.T) {
+		explainPath := filepath.Join(tmpDir, "explain.json")
+		cmd := &ConvertCommand{logger: zaptest.NewLogger(t)}
+		videoConverter := converter.NewVideoToGameConverter(metadata.Chain{}, nil, converter.ShortsInclude, 1, cmd.logger)
+		require.NoError(t, cmd.convertCSVToGames(videoConverter, tmpDir, filepath.Join(tmpDir, "output.json"), explainPath))
+
+		data, err := os.ReadFile(explainPath)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{
+				"VideoID": "video1",
+				"Title": "Test Video 1",
+				"CleanedTitle": "Test Video 1",
+				"Steam": false,
+				"Game": "Test Video 1"
+			}
+		]`, string(data))
+	})
+
+	t.Run("Unsupported Converter", func(t *testing.T) {
+		mockConverter := mockConverter.NewMockInterface(t)
+		mockConverter.EXPECT().Convert(mock.AnythingOfType("[]*model.Video")).Return(nil, nil)
+
+		cmd := &ConvertCommand{logger: zaptest.NewLogger(t)}
+		err := cmd.convertCSVToGames(mockConverter, tmpDir, filepath.Join(tmpDir, "output.json"), filepath.Join(tmpDir, "explain.md"))
+		assert.ErrorContains(t, err, "cannot explain")
+	})
+}
+
+func TestConvertCSVToGamesRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvContent := `Link,PublishedAt,Title,Description,ChannelID,VideoID,Source
+https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1 Folge 1,Test description,UCTEST123,video1,youtube`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "UCTEST123.csv"), []byte(csvContent), 0644))
+	rulesPath := filepath.Join(tmpDir, "rules.json")
+	require.NoError(t, os.WriteFile(filepath.Join(rulesPath), []byte(`{"Default": {"Cleanups": [{"Match": "Folge \\d+"}, {"Match": "\\s+", "Replace": " "}]}}`), 0644))
+
+	rules, err := readRules(rulesPath)
+	require.NoError(t, err)
+
+	outputPath := filepath.Join(tmpDir, "output.json")
+	explainPath := filepath.Join(tmpDir, "explain.json")
+	cmd := &ConvertCommand{logger: zaptest.NewLogger(t), DryRun: true}
+	videoConverter := converter.NewVideoToGameConverter(metadata.Chain{}, rules, converter.ShortsInclude, 1, cmd.logger)
+	require.NoError(t, cmd.convertCSVToGames(videoConverter, tmpDir, outputPath, explainPath))
+
+	data, err := os.ReadFile(explainPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{
+			"VideoID": "video1",
+			"Title": "Test Video 1 Folge 1",
+			"CleanedTitle": "Test Video 1 ",
+			"Steam": false,
+			"Game": "Test Video 1"
+		}
+	]`, string(data))
+	assert.NoFileExists(t, outputPath, "a dry run does not write the output")
+}
+
+func TestConvertCSVToGamesMergeReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvContent := `Link,PublishedAt,Title,Description,ChannelID,VideoID,Source
+https://www.youtube.com/watch?v=video1,2023-01-01T12:00:00Z,Test Video 1,Test description,UCTEST123,video1,youtube`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "UCTEST123.csv"), []byte(csvContent), 0644))
+
+	outputPath := filepath.Join(tmpDir, "output.json")
+	file, err := os.Create(outputPath)
+	require.NoError(t, err)
+	require.NoError(t, model.JSONWrite(file, &model.Data{Games: []*model.Game{
+		{
+			Name: "test game",
+			Content: []*model.Content{
+				{
+					Link:   "https://www.youtube.com/watch?v=existing",
+					Start:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+					Source: model.SourceYouTube,
+				},
+			},
+		},
+	}}))
+	require.NoError(t, file.Close())
+
+	mockConverter := mockConverter.NewMockInterface(t)
+	mockConverter.EXPECT().Convert(mock.AnythingOfType("[]*model.Video")).Return([]*model.Game{
+		{
+			Name: "Test Game",
+			Content: []*model.Content{
+				{
+					Link:   "https://www.youtube.com/watch?v=video1",
+					Start:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+					Source: model.SourceYouTube,
+				},
+			},
+		},
+	}, nil)
+
+	reportPath := filepath.Join(tmpDir, "report", "conflicts.json")
+	cmd := &ConvertCommand{
+		logger: zaptest.NewLogger(t),
+
+		MergePolicy: string(model.MergePreferNew),
+		MergeReport: reportPath,
+	}
+	require.NoError(t, cmd.convertCSVToGames(mockConverter, tmpDir, outputPath, ""))
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{
+			"Game": "Test Game",
+			"Field": "Name",
+			"New": "Test Game",
+			"Existing": "test game",
+			"Resolved": "Test Game"
+		},
+		{
+			"Game": "Test Game",
+			"Field": "Content.Start",
+			"Content": "https://www.youtube.com/watch?v=video1",
+			"New": "2023-01-01T12:00:00Z",
+			"Existing": "2022-01-01T00:00:00Z",
+			"Resolved": "2023-01-01T12:00:00Z"
+		}
+	]`, string(data))
 }
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	goerrors "errors"
+	"os"
+	"path/filepath"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type SchemaCommand struct {
+	logger *zap.Logger
+
+	Output string `long:"output" default:"./public/data.schema.json" description:"Output JSON Schema file path"`
+}
+
+func NewSchemaCommand(logger *zap.Logger) flags.Commander {
+	return &SchemaCommand{
+		logger: logger,
+	}
+}
+
+func (cmd *SchemaCommand) Execute(args []string) error {
+	return writeSchema(cmd.Output)
+}
+
+// writeSchema writes the JSON Schema of the data file.
+func writeSchema(outputPath string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		err = goerrors.Join(err, errors.WithStack(file.Close()))
+	}()
+
+	return model.SchemaJSONWrite(file, model.DataSchema())
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSchema(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "public", "data.schema.json")
+
+	require.NoError(t, writeSchema(outputPath))
+
+	actual, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	expected, err := os.ReadFile("../public/data.schema.json")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual), "the published schema is outdated, run the \"schema\" command")
+}
+
+func TestPublishedDataSchema(t *testing.T) {
+	// Validate with an independent draft 2020-12 implementation so that the published schema is checked by its standard semantics.
+	schemaFile, err := os.Open("../public/data.schema.json")
+	require.NoError(t, err)
+	defer schemaFile.Close()
+	schemaDocument, err := jsonschema.UnmarshalJSON(schemaFile)
+	require.NoError(t, err)
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.AssertFormat()
+	require.NoError(t, compiler.AddResource(model.SchemaID, schemaDocument))
+	schema, err := compiler.Compile(model.SchemaID)
+	require.NoError(t, err)
+
+	dataFile, err := os.Open("../public/data.json")
+	require.NoError(t, err)
+	defer dataFile.Close()
+	data, err := jsonschema.UnmarshalJSON(dataFile)
+	require.NoError(t, err)
+
+	assert.NoError(t, schema.Validate(data))
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
-	"bytes"
 	goerrors "errors"
 	"html/template"
+	"iter"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/bauersimon/grnkdb/model"
@@ -22,6 +24,9 @@ type WebCommand struct {
 	TemplatePath string `long:"template-path" default:"./web/html" description:"Template path"`
 	HTMLPath     string `long:"html-path" default:"./public" description:"HTML output path"`
 	Live         bool   `long:"live" short:"l" description:"Re-generate periodically"`
+
+	HideConfidence float64 `long:"hide-confidence" description:"Hide games with a lower match confidence"`
+	MarkConfidence float64 `long:"mark-confidence" default:"0.5" description:"Mark games with a lower match confidence as uncertain"`
 }
 
 func NewWebCommand(logger *zap.Logger) flags.Commander {
@@ -36,7 +41,7 @@ func (cmd *WebCommand) Execute(args []string) error {
 
 func (cmd *WebCommand) web(gameDataPath, templateDataPath, htmlDataPath string, loopGeneration bool) (err error) {
 	for {
-		err = webLoop(gameDataPath, templateDataPath, htmlDataPath)
+		err = webLoop(gameDataPath, templateDataPath, htmlDataPath, cmd.HideConfidence, cmd.MarkConfidence)
 		if !loopGeneration {
 			break
 		} else {
@@ -50,7 +55,92 @@ func (cmd *WebCommand) web(gameDataPath, templateDataPath, htmlDataPath string,
 	return err
 }
 
-func webLoop(gameDataPath, templateDataPath, htmlDataPath string) (err error) {
+// webGame is a game as shown on the website.
+type webGame struct {
+	*model.Game
+
+	// Uncertain denotes if the game was identified with low confidence.
+	Uncertain bool
+	// Participants holds the co-players and guests of all content, sorted by name.
+	Participants []string
+	// Status is the series status, either "completed", "abandoned" or empty if ongoing or unknown.
+	Status string
+}
+
+// abandonedAfter is the time after the last episode of an incomplete series after which it is considered abandoned.
+const abandonedAfter = 180 * 24 * time.Hour
+
+// seriesStatus returns the series status of a game at the given time.
+// Only multi-episode Let's Plays and content of unknown kind can be abandoned.
+func seriesStatus(game *model.Game, now time.Time) string {
+	isSeries := false
+	for _, content := range game.Content {
+		if content.Completed {
+			return "completed"
+		} else if content.Episodes < 2 || (content.Kind != "" && content.Kind != model.KindLetsPlay) {
+			continue
+		} else if now.Sub(content.End) < abandonedAfter {
+			return ""
+		}
+		isSeries = true
+	}
+	if isSeries {
+		return "abandoned"
+	}
+
+	return ""
+}
+
+// webPage is the data of the website.
+type webPage struct {
+	// Games holds the games to show.
+	Games []*webGame
+	// Participants holds the names of all co-players and guests of the games to filter by, sorted by name.
+	Participants []string
+}
+
+// newWebPage prepares the website data at the given time.
+func newWebPage(games iter.Seq[*model.Game], hideConfidence float64, markConfidence float64, now time.Time) *webPage {
+	page := &webPage{
+		Games: webGames(games, hideConfidence, markConfidence),
+	}
+
+	for _, game := range page.Games {
+		participants := map[string]bool{}
+		for _, content := range game.Content {
+			for _, participant := range content.Participants {
+				participants[participant] = true
+			}
+		}
+		game.Participants = slices.Sorted(maps.Keys(participants))
+		game.Status = seriesStatus(game.Game, now)
+		page.Participants = append(page.Participants, game.Participants...)
+	}
+	slices.Sort(page.Participants)
+	page.Participants = slices.Compact(page.Participants)
+
+	return page
+}
+
+// webGames prepares games for the website.
+// Games with a known confidence below the hide threshold are dropped, and those below the mark threshold are marked as uncertain.
+func webGames(games iter.Seq[*model.Game], hideConfidence float64, markConfidence float64) (result []*webGame) {
+	for game := range games {
+		isKnown := game.Confidence > 0
+		if isKnown && game.Confidence < hideConfidence {
+			continue
+		}
+
+		result = append(result, &webGame{
+			Game:      game,
+			Uncertain: isKnown && game.Confidence < markConfidence,
+		})
+	}
+
+	return result
+}
+
+func webLoop(gameDataPath, templateDataPath, htmlDataPath string, hideConfidence float64, markConfidence float64) (err error) {
 	t, err := template.ParseGlob(filepath.Join(templateDataPath, "*.html"))
 	if err != nil {
 		return errors.WithStack(err)
@@ -67,17 +157,22 @@ func webLoop(gameDataPath, templateDataPath, htmlDataPath string) (err error) {
 		err = goerrors.Join(err, errors.WithStack(file.Close()))
 	}()
 
-	data, err := os.ReadFile(gameDataPath)
+	dataFile, err := os.Open(gameDataPath)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	games, err := model.JSONRead(bytes.NewBuffer(data))
+	defer func() {
+		err = goerrors.Join(err, errors.WithStack(dataFile.Close()))
+	}()
+	gameReader, err := model.NewJSONReader(dataFile)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
 
-	if err := t.Execute(file, games); err != nil {
+	if err := t.Execute(file, newWebPage(gameReader.Games(), hideConfidence, markConfidence, time.Now())); err != nil {
 		return errors.WithStack(err)
+	} else if err := gameReader.Err(); err != nil {
+		return err
 	}
 
 	if out, err := exec.Command("tailwindcss", []string{
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebGames(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Games          []*model.Game
+		HideConfidence float64
+		MarkConfidence float64
+
+		Expected []*webGame
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, webGames(slices.Values(tc.Games), tc.HideConfidence, tc.MarkConfidence))
+		})
+	}
+
+	certain := &model.Game{Name: "Certain", Confidence: 0.9}
+	uncertain := &model.Game{Name: "Uncertain", Confidence: 0.4}
+	doubtful := &model.Game{Name: "Doubtful", Confidence: 0.1}
+	unknown := &model.Game{Name: "Unknown"}
+
+	validate(t, &testCase{
+		Name: "Mark",
+
+		Games:          []*model.Game{certain, uncertain, doubtful, unknown},
+		MarkConfidence: 0.5,
+
+		Expected: []*webGame{
+			{Game: certain},
+			{Game: uncertain, Uncertain: true},
+			{Game: doubtful, Uncertain: true},
+			{Game: unknown},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Hide",
+
+		Games:          []*model.Game{certain, uncertain, doubtful, unknown},
+		HideConfidence: 0.2,
+		MarkConfidence: 0.5,
+
+		Expected: []*webGame{
+			{Game: certain},
+			{Game: uncertain, Uncertain: true},
+			{Game: unknown},
+		},
+	})
+}
+
+func TestSeriesStatus(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Content []*model.Content
+
+		Expected string
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, seriesStatus(&model.Game{Content: tc.Content}, now))
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Ongoing",
+
+		Content: []*model.Content{
+			{Episodes: 10, End: now.AddDate(0, -1, 0)},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Completed",
+
+		Content: []*model.Content{
+			{Episodes: 10, End: now.AddDate(-2, 0, 0), Completed: true},
+		},
+
+		Expected: "completed",
+	})
+	validate(t, &testCase{
+		Name: "Abandoned",
+
+		Content: []*model.Content{
+			{Episodes: 10, End: now.AddDate(-1, 0, 0), Kind: model.KindLetsPlay},
+		},
+
+		Expected: "abandoned",
+	})
+	validate(t, &testCase{
+		Name: "Single Episode",
+
+		Content: []*model.Content{
+			{Episodes: 1, End: now.AddDate(-1, 0, 0)},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Test",
+
+		Content: []*model.Content{
+			{Episodes: 3, End: now.AddDate(-1, 0, 0), Kind: model.KindLetsTest},
+		},
+	})
+}
+
+func TestWebTemplate(t *testing.T) {
+	tmpl, err := template.ParseGlob(filepath.Join("..", "web", "html", "*.html"))
+	require.NoError(t, err)
+
+	var html bytes.Buffer
+	require.NoError(t, tmpl.Execute(&html, newWebPage(slices.Values([]*model.Game{
+		{
+			Name:       "Certain",
+			Confidence: 0.9,
+			Content: []*model.Content{
+				{
+					Link:         "https://www.youtube.com/watch?v=a",
+					Source:       model.SourceYouTube,
+					Kind:         model.KindLetsTest,
+					CoOp:         true,
+					Participants: []string{"Sarazar", "Tobinator"},
+				},
+			},
+		},
+		{Name: "Uncertain", Confidence: 0.4},
+	}), 0, 0.5, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))))
+
+	assert.Contains(t, html.String(), `data-participants="Sarazar|Tobinator" data-status=""`)
+	assert.Contains(t, html.String(), "(Let's Test, 01.01.0001, mit Sarazar, Tobinator)")
+	assert.Contains(t, html.String(), `<option value="Tobinator">Tobinator</option>`)
+	assert.Contains(t, html.String(), `Uncertain <span class="text-black/50 dark:text-white/50" title="Unsichere Zuordnung">(?)</span>`)
+}
+
+func TestPublishedDataIDs(t *testing.T) {
+	file, err := os.Open("../public/data.json")
+	require.NoError(t, err)
+	defer file.Close()
+	data, err := model.JSONRead(file)
+	require.NoError(t, err)
+
+	// The identifiers are the anchors of the games on the website.
+	gameForID := map[string]string{}
+	for _, game := range data.Games {
+		if other, ok := gameForID[game.ID]; ok {
+			assert.Failf(t, "duplicate game ID", "%q is the ID of %q and %q", game.ID, other, game.Name)
+		}
+		gameForID[game.ID] = game.Name
+	}
+}
@@ -1,90 +1,355 @@
 package converter
 
 import (
+	_ "embed"
+	"encoding/json"
+	"io"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/bauersimon/grnkdb/model"
 	"github.com/forPelevin/gomoji"
+	"github.com/pkg/errors"
 )
 
-var commonWords = map[string]bool{}
+// RulesConfig configures how video titles are cleaned up before conversion.
+type RulesConfig struct {
+	// Language is the language of channels without a configured language.
+	Language string
+	// Default holds the rules for all channels.
+	Default RuleSetConfig
+	// Languages holds additional rules by language, e.g. "de".
+	Languages map[string]RuleSetConfig `json:",omitempty"`
+	// Channels holds additional rules by channel ID.
+	Channels map[string]ChannelRulesConfig `json:",omitempty"`
+}
+
+// RuleSetConfig holds cleanup rules and stopwords.
+type RuleSetConfig struct {
+	// Cleanups holds the cleanup rules in the order they are applied.
+	Cleanups []*CleanupConfig `json:",omitempty"`
+	// Stopwords holds words that cannot identify a game on their own.
+	Stopwords []string `json:",omitempty"`
+	// Participants holds the rules to detect co-op videos and their participants.
+	Participants []*ParticipantConfig `json:",omitempty"`
+	// CoCreators holds the names of known co-creators in their canonical spelling.
+	CoCreators []string `json:",omitempty"`
+	// Kinds holds the rules to detect the content kind of a video, of which the first matching one applies.
+	Kinds []*KindConfig `json:",omitempty"`
+	// EndMarkers holds regular expressions marking the final episode of a series.
+	EndMarkers []string `json:",omitempty"`
+	// Episodes holds regular expressions to parse the episode of a video, of which the first matching one applies.
+	// The episode number is captured by a group named "number" and the season by an optional group named "season".
+	Episodes []string `json:",omitempty"`
+}
+
+// ChannelRulesConfig holds the rules of a channel.
+type ChannelRulesConfig struct {
+	// Language is the language of the channel.
+	Language string `json:",omitempty"`
+
+	RuleSetConfig
+}
 
-func init() {
-	words := "alles,der,die,das,ein,the,gronkh"
-	for _, word := range strings.Split(words, ",") {
-		commonWords[word] = true
+// CleanupConfig configures a single cleanup rule.
+type CleanupConfig struct {
+	// Match holds a regular expression to match.
+	// Will match the whole string if empty.
+	Match string `json:",omitempty"`
+	// Replace holds the replacement of matches, which can reference groups, e.g. "$1".
+	// Matches will be removed if empty.
+	Replace string `json:",omitempty"`
+	// Emojis replaces all emojis with spaces instead of matching.
+	Emojis bool `json:",omitempty"`
+}
+
+// ParticipantConfig configures the detection of co-op videos and their participants.
+type ParticipantConfig struct {
+	// Match holds a regular expression marking a video as co-op.
+	// Its first group, if any, captures the participant names separated by ",", "&", "/", "+", "und" or "and".
+	Match string
+	// Known only accepts known co-creators as participants and does not mark the video as co-op otherwise.
+	Known bool `json:",omitempty"`
+}
+
+// KindConfig configures the detection of a content kind.
+type KindConfig struct {
+	// Match holds a regular expression to match.
+	Match string
+	// Kind is the content kind of matching videos, e.g. "letstest".
+	Kind model.ContentKind
+}
+
+//go:embed rules.json
+var defaultRulesJSON string
+
+// DefaultRules returns the built-in cleanup rules.
+func DefaultRules() *Rules {
+	rules, err := ReadRules(strings.NewReader(defaultRulesJSON))
+	if err != nil {
+		panic(err)
+	}
+
+	return rules
+}
+
+// ReadRules reads cleanup rules in JSON format.
+func ReadRules(reader io.Reader) (*Rules, error) {
+	var config RulesConfig
+
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, errors.WithStack(err)
 	}
+
+	return NewRules(&config)
+}
+
+// Rules holds compiled cleanup rules.
+type Rules struct {
+	// defaultRules holds the rules of channels without channel rules.
+	defaultRules *ruleSet
+	// channelRules holds the rules by channel ID.
+	channelRules map[string]*ruleSet
+}
+
+// ruleSet holds all rules that apply to a channel.
+type ruleSet struct {
+	cleaners     []*cleaner
+	stopwords    map[string]bool
+	participants []*participantMatcher
+	// coCreators holds the canonical spelling of known co-creators by their lowercased name.
+	coCreators map[string]string
+	kinds      []*kindMatcher
+	endMarkers []*regexp.Regexp
+	episodes   []*regexp.Regexp
+}
+
+type participantMatcher struct {
+	match *regexp.Regexp
+	known bool
+}
+
+type kindMatcher struct {
+	match *regexp.Regexp
+	kind  model.ContentKind
+}
+
+// NewRules compiles cleanup rules.
+// Channel rules are applied first, then language rules and then the default rules.
+func NewRules(config *RulesConfig) (rules *Rules, err error) {
+	rules = &Rules{
+		channelRules: map[string]*ruleSet{},
+	}
+
+	rules.defaultRules, err = newRuleSet(config.Languages[config.Language], config.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	for channelID, channel := range config.Channels {
+		language := channel.Language
+		if language == "" {
+			language = config.Language
+		}
+
+		rules.channelRules[channelID], err = newRuleSet(channel.RuleSetConfig, config.Languages[language], config.Default)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "channel %q", channelID)
+		}
+	}
+
+	return rules, nil
+}
+
+func newRuleSet(configs ...RuleSetConfig) (*ruleSet, error) {
+	set := &ruleSet{
+		stopwords:  map[string]bool{},
+		coCreators: map[string]string{},
+	}
+	for _, config := range configs {
+		for _, cleanup := range config.Cleanups {
+			c := &cleaner{
+				replace: cleanup.Replace,
+				emojis:  cleanup.Emojis,
+			}
+			if cleanup.Match != "" {
+				match, err := regexp.Compile(cleanup.Match)
+				if err != nil {
+					return nil, errors.WithStack(err)
+				}
+				c.match = match
+			}
+			set.cleaners = append(set.cleaners, c)
+		}
+		for _, word := range config.Stopwords {
+			set.stopwords[strings.ToLower(word)] = true
+		}
+		for _, participant := range config.Participants {
+			match, err := regexp.Compile(participant.Match)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			set.participants = append(set.participants, &participantMatcher{
+				match: match,
+				known: participant.Known,
+			})
+		}
+		for _, name := range config.CoCreators {
+			set.coCreators[strings.ToLower(name)] = name
+		}
+		for _, kind := range config.Kinds {
+			if !kind.Kind.Valid() {
+				return nil, errors.Errorf("unknown content kind %q", kind.Kind)
+			}
+			match, err := regexp.Compile(kind.Match)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			set.kinds = append(set.kinds, &kindMatcher{
+				match: match,
+				kind:  kind.Kind,
+			})
+		}
+		for _, marker := range config.EndMarkers {
+			match, err := regexp.Compile(marker)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			set.endMarkers = append(set.endMarkers, match)
+		}
+		for _, episode := range config.Episodes {
+			match, err := regexp.Compile(episode)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			} else if match.SubexpIndex("number") < 0 {
+				return nil, errors.Errorf("episode expression %q has no group named \"number\"", episode)
+			}
+			set.episodes = append(set.episodes, match)
+		}
+	}
+
+	return set, nil
+}
+
+// forChannel returns the rules of a channel.
+func (r *Rules) forChannel(channelID string) *ruleSet {
+	if set, ok := r.channelRules[channelID]; ok {
+		return set
+	}
+
+	return r.defaultRules
+}
+
+// isStopword checks if a text is a stopword for the channel.
+func (r *Rules) isStopword(channelID string, text string) bool {
+	return r.forChannel(channelID).stopwords[strings.TrimSpace(text)]
 }
 
 type cleaner struct {
 	// match holds a regular expression to match.
 	// Will match the whole string if "nil".
 	match *regexp.Regexp
-	// replace holds a replace function.
-	// Matches will be replaced with "" if "nil".
-	replace func(in string, match [][]string) string
+	// replace holds the replacement of matches.
+	replace string
+	// emojis replaces all emojis with spaces instead of matching.
+	emojis bool
 }
 
 func (c cleaner) process(s string) string {
-	if c.match == nil && c.replace == nil {
-		return ""
+	if c.emojis {
+		return gomoji.ReplaceEmojisWith(s, ' ')
+	} else if c.match == nil {
+		return c.replace
+	}
+
+	return c.match.ReplaceAllString(s, c.replace)
+}
+
+var participantSeparatorRE = regexp.MustCompile(`(?i)\s*(?:,|&|/|\+|\bund\b|\band\b)\s*`)
+
+// participants detects if a video is co-op and extracts its participants from the title.
+// The title must not be cleaned up yet.
+func (r *Rules) participants(video *model.Video) (participants []string, coOp bool) {
+	set := r.forChannel(video.ChannelID)
+	for _, p := range set.participants {
+		for _, match := range p.match.FindAllStringSubmatch(video.Title, -1) {
+			if len(match) < 2 {
+				coOp = coOp || !p.known
+
+				continue
+			}
+
+			for _, name := range participantSeparatorRE.Split(match[1], -1) {
+				name = strings.TrimSpace(name)
+				if canonical, ok := set.coCreators[strings.ToLower(name)]; ok {
+					name = canonical
+				} else if p.known || len([]rune(name)) < 2 {
+					continue
+				}
+
+				coOp = true
+				if !slices.Contains(participants, name) {
+					participants = append(participants, name)
+				}
+			}
+			coOp = coOp || !p.known
+		}
+	}
+
+	return participants, coOp
+}
+
+// kind detects the content kind of a video from its title, empty if unknown.
+// The title must not be cleaned up yet.
+func (r *Rules) kind(video *model.Video) model.ContentKind {
+	for _, k := range r.forChannel(video.ChannelID).kinds {
+		if k.match.MatchString(video.Title) {
+			return k.kind
+		}
 	}
 
-	var match [][]string
-	if c.match != nil {
-		match = c.match.FindAllStringSubmatch(s, -1)
-		if len(match) == 0 {
-			return s
+	return ""
+}
+
+// isFinal checks if a video is marked as the final episode of a series.
+// The title must not be cleaned up yet.
+func (r *Rules) isFinal(video *model.Video) bool {
+	for _, marker := range r.forChannel(video.ChannelID).endMarkers {
+		if marker.MatchString(video.Title) {
+			return true
 		}
 	}
 
-	if c.replace == nil {
-		for _, m := range match {
-			s = strings.ReplaceAll(s, m[0], "")
+	return false
+}
+
+// episode parses the episode of a video from its title, zero if unknown.
+// The title must not be cleaned up yet.
+func (r *Rules) episode(video *model.Video) (episode model.Episode) {
+	for _, e := range r.forChannel(video.ChannelID).episodes {
+		match := e.FindStringSubmatch(video.Title)
+		if match == nil {
+			continue
 		}
-		return s
+
+		episode.Number, _ = strconv.Atoi(match[e.SubexpIndex("number")])
+		if i := e.SubexpIndex("season"); i >= 0 {
+			episode.Season, _ = strconv.Atoi(match[i])
+		}
+
+		return episode
 	}
 
-	return c.replace(s, match)
-}
-
-var cleanups = []*cleaner{
-	// Common tags.
-	{regexp.MustCompile(`(?i)Let's (Play|Test)`), nil},
-	{regexp.MustCompile(`(?i)\(?Ende\)?`), nil},
-	{regexp.MustCompile(`(?i)\(?Demo\)?`), nil},
-	{regexp.MustCompile(`(?i)\(?Angespielt\)?`), nil},
-	{regexp.MustCompile(`(?i)\(?Preview\)?`), nil},
-	{regexp.MustCompile(`(?i)\(LPT[^\)]*\)`), nil},
-	{regexp.MustCompile(`M\.?e\.?t\.?t\.?`), nil},
-	// Episode numbers.
-	{regexp.MustCompile(`#\d+`), nil},
-	{regexp.MustCompile(`\D\d\d\d\:`), nil},
-	{regexp.MustCompile(`\d+/\d+`), nil},
-	{regexp.MustCompile(`Folge\s+\d+`), nil},
-	{regexp.MustCompile(`S\d+E\d+`), nil},
-	// Anything in squared brackets.
-	{regexp.MustCompile(`\[[^\[]*\]`), nil},
-	// All non-character or non-digit characters.
-	{regexp.MustCompile(`[^\p{L}\p{N}\s\:]+`), nil},
-	{
-		replace: func(in string, match [][]string) string {
-			return gomoji.ReplaceEmojisWith(in, ' ')
-		},
-	},
-	{
-		replace: func(in string, match [][]string) string {
-			return whitespaceRE.ReplaceAllString(in, " ")
-		},
-	},
-}
-
-var whitespaceRE = regexp.MustCompile(`\s+`)
-
-func cleanupVideoMeta(videos []*model.Video) {
+	return episode
+}
+
+func (r *Rules) cleanupVideoMeta(videos []*model.Video) {
 	for _, video := range videos {
-		for _, c := range cleanups {
+		for _, c := range r.forChannel(video.ChannelID).cleaners {
 			video.Title = c.process(video.Title)
 		}
 	}
@@ -0,0 +1,369 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesCleanupVideoMeta(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Rules string
+		Video *model.Video
+
+		Expected string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			rules := DefaultRules()
+			if tc.Rules != "" {
+				var err error
+				rules, err = ReadRules(strings.NewReader(tc.Rules))
+				require.NoError(t, err)
+			}
+
+			rules.cleanupVideoMeta([]*model.Video{tc.Video})
+
+			assert.Equal(t, tc.Expected, tc.Video.Title)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Default",
+
+		Video: &model.Video{
+			Title: "Let's Play Minecraft #123 [Deutsch] - Alles auf Anfang (Ende) 🎮",
+		},
+
+		Expected: " Minecraft Alles auf Anfang ",
+	})
+	validate(t, &testCase{
+		Name: "Replace",
+
+		Rules: `{"Default": {"Cleanups": [{"Match": "(\\w+) (\\w+)", "Replace": "$2 $1"}]}}`,
+		Video: &model.Video{
+			Title: "Minecraft Classic",
+		},
+
+		Expected: "Classic Minecraft",
+	})
+	validate(t, &testCase{
+		Name: "Language",
+
+		Rules: `{
+			"Language": "en",
+			"Default": {"Cleanups": [{"Match": "\\s+", "Replace": " "}]},
+			"Languages": {
+				"de": {"Cleanups": [{"Match": "Folge \\d+"}]},
+				"en": {"Cleanups": [{"Match": "Episode \\d+"}]}
+			}
+		}`,
+		Video: &model.Video{
+			Title: "Minecraft Episode 1 Folge 2",
+		},
+
+		Expected: "Minecraft Folge 2",
+	})
+	validate(t, &testCase{
+		Name: "Channel",
+
+		Rules: `{
+			"Language": "en",
+			"Default": {"Cleanups": [{"Match": "\\s+", "Replace": " "}]},
+			"Languages": {
+				"de": {"Cleanups": [{"Match": "Folge \\d+"}]},
+				"en": {"Cleanups": [{"Match": "Episode \\d+"}]}
+			},
+			"Channels": {
+				"abc": {"Language": "de", "Cleanups": [{"Match": "GronkhTV"}]}
+			}
+		}`,
+		Video: &model.Video{
+			Title:     "GronkhTV Minecraft Episode 1 Folge 2",
+			ChannelID: "abc",
+		},
+
+		Expected: " Minecraft Episode 1 ",
+	})
+}
+
+func TestReadRules(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Rules string
+
+		Error string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ReadRules(strings.NewReader(tc.Rules))
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Valid",
+
+		Rules: `{"Default": {"Cleanups": [{"Match": "#\\d+"}], "Stopwords": ["the"]}}`,
+	})
+	validate(t, &testCase{
+		Name: "Invalid Expression",
+
+		Rules: `{"Default": {"Cleanups": [{"Match": "("}]}}`,
+
+		Error: "missing closing )",
+	})
+	validate(t, &testCase{
+		Name: "Invalid Channel Expression",
+
+		Rules: `{"Channels": {"abc": {"Cleanups": [{"Match": "("}]}}}`,
+
+		Error: `channel "abc"`,
+	})
+	validate(t, &testCase{
+		Name: "Unknown Kind",
+
+		Rules: `{"Default": {"Kinds": [{"Match": "Let's Speedrun", "Kind": "speedrun"}]}}`,
+
+		Error: `unknown content kind "speedrun"`,
+	})
+	validate(t, &testCase{
+		Name: "Episode Without Number",
+
+		Rules: `{"Default": {"Episodes": ["#(\\d+)"]}}`,
+
+		Error: `has no group named "number"`,
+	})
+	validate(t, &testCase{
+		Name: "Unknown Field",
+
+		Rules: `{"Cleanups": []}`,
+
+		Error: "unknown field",
+	})
+}
+
+func TestRulesParticipants(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Title string
+
+		ExpectedParticipants []string
+		ExpectedCoOp         bool
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			participants, coOp := DefaultRules().participants(&model.Video{
+				Title: tc.Title,
+			})
+
+			assert.Equal(t, tc.ExpectedParticipants, participants)
+			assert.Equal(t, tc.ExpectedCoOp, coOp)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Solo",
+
+		Title: "Minecraft #12 Spaß mit dem Creeper",
+	})
+	validate(t, &testCase{
+		Name: "LPT",
+
+		Title: "LPT Minecraft #3",
+
+		ExpectedCoOp: true,
+	})
+	validate(t, &testCase{
+		Name: "LPT Participants",
+
+		Title: "Minecraft (LPT mit Sarazar & Tobinator) #12",
+
+		ExpectedParticipants: []string{"Sarazar", "Tobinator"},
+		ExpectedCoOp:         true,
+	})
+	validate(t, &testCase{
+		Name: "Known Co-Creators",
+
+		Title: "Minecraft mit tobinator und Sarazar im Nether #2",
+
+		ExpectedParticipants: []string{"Tobinator", "Sarazar"},
+		ExpectedCoOp:         true,
+	})
+	validate(t, &testCase{
+		Name: "Featuring",
+
+		Title: "Minecraft feat. Unbekannt #2",
+
+		ExpectedParticipants: []string{"Unbekannt"},
+		ExpectedCoOp:         true,
+	})
+}
+
+func TestRulesKind(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Title string
+
+		Expected model.ContentKind
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, DefaultRules().kind(&model.Video{
+				Title: tc.Title,
+			}))
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Unknown",
+
+		Title: "Minecraft #12 Alles auf Anfang",
+	})
+	validate(t, &testCase{
+		Name: "Let's Play",
+
+		Title: "Let's Play Minecraft #12 Alles auf Anfang",
+
+		Expected: model.KindLetsPlay,
+	})
+	validate(t, &testCase{
+		Name: "Let's Test",
+
+		Title: "Let's Test: Subnautica",
+
+		Expected: model.KindLetsTest,
+	})
+	validate(t, &testCase{
+		Name: "Angespielt",
+
+		Title: "Subnautica (Angespielt)",
+
+		Expected: model.KindAngespielt,
+	})
+	validate(t, &testCase{
+		Name: "Demo Before Let's Play",
+
+		Title: "Let's Play Resident Evil 2 Demo",
+
+		Expected: model.KindDemo,
+	})
+	validate(t, &testCase{
+		Name: "Stream",
+
+		Title: "Minecraft Livestream",
+
+		Expected: model.KindStream,
+	})
+}
+
+func TestRulesIsFinal(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Title string
+
+		Expected bool
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, DefaultRules().isFinal(&model.Video{
+				Title: tc.Title,
+			}))
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Episode",
+
+		Title: "Let's Play Minecraft #12 Alles auf Anfang",
+	})
+	validate(t, &testCase{
+		Name: "Word Containing Marker",
+
+		Title: "Let's Play Legende von Zelda #12",
+	})
+	validate(t, &testCase{
+		Name: "Ende",
+
+		Title: "Let's Play Minecraft #123 (Ende)",
+
+		Expected: true,
+	})
+	validate(t, &testCase{
+		Name: "Finale",
+
+		Title: "Subnautica #40 Das große Finale",
+
+		Expected: true,
+	})
+	validate(t, &testCase{
+		Name: "Letzte Folge",
+
+		Title: "Subnautica #40 - Letzte Folge",
+
+		Expected: true,
+	})
+}
+
+func TestRulesEpisode(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Title string
+
+		Expected model.Episode
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, DefaultRules().episode(&model.Video{
+				Title: tc.Title,
+			}))
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Unknown",
+
+		Title: "Angespielt: Subnautica",
+	})
+	validate(t, &testCase{
+		Name: "Hash",
+
+		Title: "Let's Play Minecraft #123 [Deutsch] [HD] - Alles auf Anfang",
+
+		Expected: model.Episode{Number: 123},
+	})
+	validate(t, &testCase{
+		Name: "Folge",
+
+		Title: "Minecraft Folge 12: Alles auf Anfang",
+
+		Expected: model.Episode{Number: 12},
+	})
+	validate(t, &testCase{
+		Name: "Season",
+
+		Title: "Minecraft S02E03 Alles auf Anfang",
+
+		Expected: model.Episode{Season: 2, Number: 3},
+	})
+}
@@ -0,0 +1,191 @@
+package converter
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bauersimon/grnkdb/model"
+)
+
+// clusterKey is a token prefix or suffix of a title.
+type clusterKey struct {
+	// text holds the lowercased tokens of the prefix or suffix.
+	text string
+	// suffix denotes if the key is a suffix rather than a prefix.
+	suffix bool
+}
+
+// parent returns the key that is one token shorter.
+func (k clusterKey) parent() (parent clusterKey, ok bool) {
+	if k.suffix {
+		_, text, ok := strings.Cut(k.text, " ")
+		return clusterKey{text: text, suffix: true}, ok
+	}
+
+	i := strings.LastIndexByte(k.text, ' ')
+	if i < 0 {
+		return clusterKey{}, false
+	}
+
+	return clusterKey{text: k.text[:i]}, true
+}
+
+func compareClusterKeys(a, b clusterKey) int {
+	if c := strings.Compare(a.text, b.text); c != 0 {
+		return c
+	} else if a.suffix == b.suffix {
+		return 0
+	} else if !a.suffix {
+		return -1
+	}
+
+	return +1
+}
+
+// isSpecifier checks if a key text of the given videos is meaningful enough to identify a game.
+// The text must not be a stopword in any of the channels of the videos.
+func isSpecifier(rules *Rules, text string, videos []*model.Video) bool {
+	if len(strings.TrimSpace(text)) <= 2 {
+		return false
+	}
+	for _, video := range videos {
+		if rules.isStopword(video.ChannelID, text) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tokenIndex is an inverted index from the token prefixes and suffixes of titles to videos.
+type tokenIndex map[clusterKey][]*model.Video
+
+// newTokenIndex indexes all token prefixes and suffixes of the video titles.
+func newTokenIndex(videos []*model.Video) tokenIndex {
+	index := tokenIndex{}
+	for _, video := range videos {
+		tokens := strings.Fields(strings.ToLower(video.Title))
+		for i := 1; i <= len(tokens); i++ {
+			prefix := clusterKey{text: strings.Join(tokens[:i], " ")}
+			index[prefix] = append(index[prefix], video)
+			suffix := clusterKey{text: strings.Join(tokens[len(tokens)-i:], " "), suffix: true}
+			index[suffix] = append(index[suffix], video)
+		}
+	}
+
+	return index
+}
+
+// candidates returns the keys that can identify a game, with known game names first and the others by support, length and publish density.
+func (index tokenIndex) candidates(rules *Rules, knownGames map[string]bool, position map[*model.Video]int) (candidates []clusterKey) {
+	maxChildSupport := map[clusterKey]int{}
+	for key, videos := range index {
+		if parent, ok := key.parent(); ok {
+			maxChildSupport[parent] = max(maxChildSupport[parent], len(videos))
+		}
+	}
+
+	for key, videos := range index {
+		if !isSpecifier(rules, key.text, videos) {
+			continue
+		} else if knownGames[key.text] {
+			candidates = append(candidates, key)
+
+			continue
+		} else if len(videos) < 2 {
+			continue
+		} else if maxChildSupport[key] == len(videos) {
+			continue
+		} else if parent, ok := key.parent(); ok && isSpecifier(rules, parent.text, index[parent]) && len(index[parent]) > len(videos) {
+			continue
+		}
+
+		candidates = append(candidates, key)
+	}
+
+	score := map[clusterKey]float64{}
+	for _, key := range candidates {
+		videos := index[key]
+		first, last := map[string]int{}, map[string]int{}
+		for _, video := range videos {
+			if p, ok := first[video.ChannelID]; !ok || position[video] < p {
+				first[video.ChannelID] = position[video]
+			}
+			last[video.ChannelID] = max(last[video.ChannelID], position[video])
+		}
+		span := 0
+		for channelID := range first {
+			span += last[channelID] - first[channelID] + 1
+		}
+		density := float64(len(videos)) / float64(span)
+		score[key] = float64(len(videos)*utf8.RuneCountInString(key.text)) * density
+	}
+	slices.SortFunc(candidates, func(a, b clusterKey) int {
+		if knownGames[a.text] != knownGames[b.text] {
+			if knownGames[a.text] {
+				return -1
+			}
+
+			return +1
+		} else if c := cmp.Compare(score[b], score[a]); c != 0 {
+			return c
+		}
+
+		return compareClusterKeys(a, b)
+	})
+
+	return candidates
+}
+
+// clusterVideos assigns each video a game specifier by clustering the videos over the shared token prefixes and suffixes of their titles.
+// Videos that already have a specifier keep it. Videos without a shared prefix or suffix are identified by their title.
+// Stopwords of the rules never identify a cluster.
+func clusterVideos(videos []*model.Video, specifierForVideo map[*model.Video]string, rules *Rules) {
+	var unassigned []*model.Video
+	clusterSize := map[string]int{}
+	for _, video := range videos {
+		if specifier, ok := specifierForVideo[video]; ok {
+			clusterSize[specifier]++
+		} else {
+			unassigned = append(unassigned, video)
+		}
+	}
+	knownGames := map[string]bool{}
+	for specifier := range maps.Keys(clusterSize) {
+		knownGames[specifier] = true
+	}
+
+	position := map[*model.Video]int{}
+	channelVideos := map[string]int{}
+	for _, video := range slices.SortedStableFunc(slices.Values(videos), compareVideosByChannel) {
+		position[video] = channelVideos[video.ChannelID]
+		channelVideos[video.ChannelID]++
+	}
+
+	index := newTokenIndex(unassigned)
+	for _, key := range index.candidates(rules, knownGames, position) {
+		var members []*model.Video
+		for _, video := range index[key] {
+			if _, ok := specifierForVideo[video]; !ok {
+				members = append(members, video)
+			}
+		}
+		if len(members) == 0 || (len(members) < 2 && clusterSize[key.text] == 0) {
+			continue
+		}
+
+		for _, video := range members {
+			specifierForVideo[video] = key.text
+		}
+		clusterSize[key.text] += len(members)
+	}
+
+	for _, video := range unassigned {
+		if _, ok := specifierForVideo[video]; !ok {
+			specifierForVideo[video] = video.Title
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterVideos(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Rules    string
+		Titles   []string
+		Assigned map[int]string
+
+		Expected []string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			videos := make([]*model.Video, len(tc.Titles))
+			specifierForVideo := map[*model.Video]string{}
+			for i, title := range tc.Titles {
+				videos[i] = &model.Video{
+					Title:       title,
+					PublishedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i),
+				}
+				if specifier, ok := tc.Assigned[i]; ok {
+					specifierForVideo[videos[i]] = specifier
+				}
+			}
+
+			rules := DefaultRules()
+			if tc.Rules != "" {
+				var err error
+				rules, err = ReadRules(strings.NewReader(tc.Rules))
+				require.NoError(t, err)
+			}
+
+			clusterVideos(videos, specifierForVideo, rules)
+
+			actual := make([]string, len(videos))
+			for i, video := range videos {
+				actual[i] = specifierForVideo[video]
+			}
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Prefix",
+
+		Titles: []string{
+			"Minecraft Alles auf Anfang",
+			"Minecraft Alles klar",
+			"Minecraft Inselkoller",
+		},
+
+		Expected: []string{
+			"minecraft",
+			"minecraft",
+			"minecraft",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Suffix",
+
+		Titles: []string{
+			"Der Mann mit dem Hut INDIANA JONES AND THE GREAT CIRCLE",
+			"Schwarze Hemden INDIANA JONES AND THE GREAT CIRCLE",
+		},
+
+		Expected: []string{
+			"indiana jones and the great circle",
+			"indiana jones and the great circle",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "No Match",
+
+		Titles: []string{
+			"Minecraft Alles auf Anfang",
+			"Das ist ein Test",
+		},
+
+		Expected: []string{
+			"Minecraft Alles auf Anfang",
+			"Das ist ein Test",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Common Word",
+
+		Titles: []string{
+			"Das Ende",
+			"Das Spiel",
+		},
+
+		Expected: []string{
+			"Das Ende",
+			"Das Spiel",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Stopword",
+
+		Rules: `{"Default": {"Stopwords": ["minecraft"]}}`,
+		Titles: []string{
+			"Minecraft Alles auf Anfang",
+			"Minecraft Inselkoller",
+		},
+
+		Expected: []string{
+			"Minecraft Alles auf Anfang",
+			"Minecraft Inselkoller",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Scattered Phrase",
+
+		Titles: []string{
+			"Valheim Große Plan um Mitternacht",
+			"Valheim Dunkle Höhle",
+			"Valheim Letzte Rettung",
+			"Terraria Neue Basis",
+			"Terraria Stille Nacht",
+			"Terraria Goldene Mine um Mitternacht",
+			"Subnautica Tiefe Quelle",
+			"Subnautica Wilde Küste um Mitternacht",
+			"Subnautica Eisige Grenze",
+		},
+
+		Expected: []string{
+			"valheim",
+			"valheim",
+			"valheim",
+			"terraria",
+			"terraria",
+			"terraria",
+			"subnautica",
+			"subnautica",
+			"subnautica",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Known Game",
+
+		Titles: []string{
+			"Große Plan Portal 2",
+			"Dunkle Höhle Portal 2",
+			"Letzte Rettung PORTAL 2",
+		},
+		Assigned: map[int]string{
+			0: "portal 2",
+		},
+
+		Expected: []string{
+			"portal 2",
+			"portal 2",
+			"portal 2",
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package converter
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/bauersimon/grnkdb/model"
+)
+
+// Weights of the evidence behind a game match, each between 0 and 1.
+const (
+	// confidenceSteam is the confidence of a game resolved via a Steam store link.
+	confidenceSteam = 1.0
+	// confidenceStoreLink is the confidence of a game resolved via another store link.
+	confidenceStoreLink = 0.8
+	// confidencePlaylist is the confidence of a game whose videos all share a playlist.
+	confidencePlaylist = 0.7
+	// confidenceClusterSize is the confidence of a game with infinitely many videos.
+	confidenceClusterSize = 0.6
+	// confidenceMatchLength is the confidence of a game with a shared title of at least matchLengthSaturation characters.
+	confidenceMatchLength = 0.4
+	// confidenceTitle is the confidence of a game that is only identified by the title of a single video.
+	confidenceTitle = 0.1
+)
+
+// matchLengthSaturation is the shared title length at which the match length is fully trusted.
+const matchLengthSaturation = 16
+
+// gameEvidence holds the evidence behind a game match.
+type gameEvidence struct {
+	// steam denotes if any video links the game on Steam.
+	steam bool
+	// storeLink denotes if any video links the game in another store.
+	storeLink bool
+	// videos holds the videos of the game.
+	videos []*model.Video
+	// key holds the shared lowercased title of the videos.
+	key string
+}
+
+// confidence combines the independent evidence behind a game match into a score between 0 and 1.
+// Each piece of evidence is treated as an independent chance of the match being correct.
+func (e *gameEvidence) confidence() float64 {
+	evidence := []float64{confidenceTitle}
+	if e.steam {
+		evidence = append(evidence, confidenceSteam)
+	}
+	if e.storeLink {
+		evidence = append(evidence, confidenceStoreLink)
+	}
+	if len(e.videos) > 1 {
+		evidence = append(evidence,
+			confidencePlaylist*e.playlistShare(),
+			confidenceClusterSize*(1-1/float64(len(e.videos))),
+			confidenceMatchLength*min(float64(utf8.RuneCountInString(e.key))/matchLengthSaturation, 1),
+		)
+	}
+
+	doubt := 1.0
+	for _, e := range evidence {
+		doubt *= 1 - e
+	}
+
+	return math.Round((1-doubt)*100) / 100
+}
+
+// playlistShare returns the share of videos in the most common playlist.
+func (e *gameEvidence) playlistShare() float64 {
+	videosInPlaylist := map[string]int{}
+	maxVideos := 0
+	for _, video := range e.videos {
+		if video.Playlist == "" {
+			continue
+		}
+		videosInPlaylist[video.Playlist]++
+		maxVideos = max(maxVideos, videosInPlaylist[video.Playlist])
+	}
+
+	return float64(maxVideos) / float64(len(e.videos))
+}
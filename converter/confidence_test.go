@@ -0,0 +1,87 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameEvidenceConfidence(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Evidence *gameEvidence
+
+		Expected float64
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.Evidence.confidence())
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Title",
+
+		Evidence: &gameEvidence{
+			videos: []*model.Video{{}},
+			key:    "minecraft alles auf anfang",
+		},
+
+		Expected: 0.1,
+	})
+	validate(t, &testCase{
+		Name: "Steam",
+
+		Evidence: &gameEvidence{
+			steam:  true,
+			videos: []*model.Video{{}},
+			key:    "minecraft",
+		},
+
+		Expected: 1,
+	})
+	validate(t, &testCase{
+		Name: "Store Link",
+
+		Evidence: &gameEvidence{
+			storeLink: true,
+			videos:    []*model.Video{{}},
+			key:       "minecraft",
+		},
+
+		Expected: 0.82,
+	})
+	validate(t, &testCase{
+		Name: "Short Match",
+
+		Evidence: &gameEvidence{
+			videos: []*model.Video{{}, {}},
+			key:    "ark",
+		},
+
+		Expected: 0.42,
+	})
+	validate(t, &testCase{
+		Name: "Playlist",
+
+		Evidence: &gameEvidence{
+			videos: []*model.Video{{Playlist: "a"}, {Playlist: "a"}},
+			key:    "ark",
+		},
+
+		Expected: 0.83,
+	})
+	validate(t, &testCase{
+		Name: "Partial Playlist",
+
+		Evidence: &gameEvidence{
+			videos: []*model.Video{{Playlist: "a"}, {Playlist: "b"}, {}, {}},
+			key:    "ark",
+		},
+
+		Expected: 0.62,
+	})
+}
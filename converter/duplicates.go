@@ -0,0 +1,86 @@
+package converter
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bauersimon/grnkdb/model"
+)
+
+// duplicateDurationTolerance is the maximum difference in duration of two copies of the same video.
+const duplicateDurationTolerance = 5 * time.Second
+
+// duplicateReuploadWindow is the maximum time between a video and its re-upload on the same channel if their durations are unknown.
+const duplicateReuploadWindow = 7 * 24 * time.Hour
+
+// duplicateKey identifies videos that could be copies of each other.
+type duplicateKey struct {
+	// title holds the normalized cleaned title.
+	title string
+	// episode holds the episode parsed from the original title.
+	episode model.Episode
+	// episodeTitle holds the normalized episode title split off with the title template, so that episodes of different runs sharing a game title and number stay apart.
+	episodeTitle string
+}
+
+// deduplicateVideos detects re-uploads and mirrors of videos within and across channels.
+// Videos are duplicates if they have the same ID, or the same cleaned title, episode and episode title as well as the same duration.
+// Videos with unknown durations need the same original title instead, and have to be on different channels or published within "duplicateReuploadWindow".
+// The earliest copy is considered the original, and the returned videos only hold the originals in their given order.
+// The original titles are given by video.
+func deduplicateVideos(videos []*model.Video, originalTitle map[*model.Video]string) (originals []*model.Video, originalForDuplicate map[*model.Video]*model.Video) {
+	ordered := slices.SortedStableFunc(slices.Values(videos), compareVideos)
+
+	originalForDuplicate = map[*model.Video]*model.Video{}
+	originalForID := map[string]*model.Video{}
+	candidatesForKey := map[duplicateKey][]*model.Video{}
+	for _, video := range ordered {
+		if original, ok := originalForID[video.VideoID]; ok {
+			originalForDuplicate[video] = original
+
+			continue
+		}
+		originalForID[video.VideoID] = video
+
+		title := strings.ToLower(strings.Join(strings.Fields(video.Title), " "))
+		if len([]rune(title)) <= 2 {
+			continue
+		}
+		key := duplicateKey{
+			title:        title,
+			episode:      video.Episode,
+			episodeTitle: strings.ToLower(strings.Join(strings.Fields(video.EpisodeTitle), " ")),
+		}
+		if i := slices.IndexFunc(candidatesForKey[key], func(candidate *model.Video) bool {
+			return isSameVideo(candidate, video, originalTitle)
+		}); i >= 0 {
+			originalForDuplicate[video] = candidatesForKey[key][i]
+
+			continue
+		}
+		candidatesForKey[key] = append(candidatesForKey[key], video)
+	}
+
+	for _, video := range videos {
+		if _, ok := originalForDuplicate[video]; !ok {
+			originals = append(originals, video)
+		}
+	}
+
+	return originals, originalForDuplicate
+}
+
+// isSameVideo checks if two videos with the same cleaned title are copies of each other.
+func isSameVideo(a, b *model.Video, originalTitle map[*model.Video]string) bool {
+	if a.Duration != 0 && b.Duration != 0 {
+		difference := time.Duration(a.Duration - b.Duration)
+		return difference.Abs() <= duplicateDurationTolerance
+	} else if a.Duration != 0 || b.Duration != 0 {
+		return false
+	} else if a.ChannelID == b.ChannelID && a.PublishedAt.Sub(b.PublishedAt).Abs() > duplicateReuploadWindow {
+		return false
+	}
+
+	return strings.EqualFold(strings.Join(strings.Fields(originalTitle[a]), " "), strings.Join(strings.Fields(originalTitle[b]), " "))
+}
@@ -0,0 +1,155 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicateVideos(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Videos []*model.Video
+		// OriginalTitles holds the original titles by video ID.
+		OriginalTitles map[string]string
+
+		// ExpectedOriginals holds the video IDs of the originals.
+		ExpectedOriginals []string
+		// ExpectedDuplicates holds the video ID of the original by video ID of the duplicate.
+		ExpectedDuplicates map[string]string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			originalTitle := map[*model.Video]string{}
+			for _, video := range tc.Videos {
+				originalTitle[video] = tc.OriginalTitles[video.VideoID]
+			}
+
+			originals, originalForDuplicate := deduplicateVideos(tc.Videos, originalTitle)
+
+			actualOriginals := []string{}
+			for _, video := range originals {
+				actualOriginals = append(actualOriginals, video.VideoID+"@"+video.ChannelID)
+			}
+			assert.Equal(t, tc.ExpectedOriginals, actualOriginals)
+			actualDuplicates := map[string]string{}
+			for duplicate, original := range originalForDuplicate {
+				actualDuplicates[duplicate.VideoID+"@"+duplicate.ChannelID] = original.VideoID + "@" + original.ChannelID
+			}
+			assert.Equal(t, tc.ExpectedDuplicates, actualDuplicates)
+		})
+	}
+
+	day := func(d int) time.Time {
+		return time.Date(2025, 1, d, 19, 0, 0, 0, time.UTC)
+	}
+
+	validate(t, &testCase{
+		Name: "Distinct",
+
+		Videos: []*model.Video{
+			{VideoID: "a", Title: " Minecraft Alles auf Anfang", Episode: model.Episode{Number: 1}, Duration: model.Duration(time.Hour), PublishedAt: day(1)},
+			{VideoID: "b", Title: " Minecraft Alles auf Anfang", Episode: model.Episode{Number: 2}, Duration: model.Duration(time.Hour), PublishedAt: day(2)},
+			{VideoID: "c", Title: " Minecraft Inselkoller", Episode: model.Episode{Number: 3}, Duration: model.Duration(time.Hour), PublishedAt: day(3)},
+		},
+
+		ExpectedOriginals:  []string{"a@", "b@", "c@"},
+		ExpectedDuplicates: map[string]string{},
+	})
+	validate(t, &testCase{
+		Name: "Same ID",
+
+		Videos: []*model.Video{
+			{VideoID: "a", Title: " Minecraft Alles auf Anfang", ChannelID: "2", PublishedAt: day(1)},
+			{VideoID: "a", Title: " Minecraft Alles auf Anfang", ChannelID: "1", PublishedAt: day(1)},
+		},
+
+		ExpectedOriginals:  []string{"a@2"},
+		ExpectedDuplicates: map[string]string{"a@1": "a@2"},
+	})
+	validate(t, &testCase{
+		Name: "Mirror",
+
+		Videos: []*model.Video{
+			{VideoID: "b", Title: " Minecraft Alles auf Anfang", ChannelID: "mirror", Episode: model.Episode{Number: 1}, Duration: model.Duration(time.Hour + 2*time.Second), PublishedAt: day(3)},
+			{VideoID: "a", Title: " Minecraft Alles  auf Anfang", ChannelID: "main", Episode: model.Episode{Number: 1}, Duration: model.Duration(time.Hour), PublishedAt: day(1)},
+		},
+
+		ExpectedOriginals:  []string{"a@main"},
+		ExpectedDuplicates: map[string]string{"b@mirror": "a@main"},
+	})
+	validate(t, &testCase{
+		Name: "Different Duration",
+
+		Videos: []*model.Video{
+			{VideoID: "a", Title: " Minecraft Alles auf Anfang", Duration: model.Duration(time.Hour), PublishedAt: day(1)},
+			{VideoID: "b", Title: " Minecraft Alles auf Anfang", Duration: model.Duration(2 * time.Hour), PublishedAt: day(2)},
+		},
+
+		ExpectedOriginals:  []string{"a@", "b@"},
+		ExpectedDuplicates: map[string]string{},
+	})
+	validate(t, &testCase{
+		Name: "Different Episode Title",
+
+		Videos: []*model.Video{
+			{VideoID: "a", Title: "Minecraft", Episode: model.Episode{Number: 1}, EpisodeTitle: "Alles auf Anfang", Duration: model.Duration(time.Hour), PublishedAt: time.Date(2014, 1, 1, 19, 0, 0, 0, time.UTC)},
+			{VideoID: "b", Title: "Minecraft", Episode: model.Episode{Number: 1}, EpisodeTitle: "Neue Welt", Duration: model.Duration(time.Hour), PublishedAt: time.Date(2023, 1, 1, 19, 0, 0, 0, time.UTC)},
+			{VideoID: "c", Title: "Minecraft", Episode: model.Episode{Number: 1}, EpisodeTitle: "Neue  welt", Duration: model.Duration(time.Hour), PublishedAt: time.Date(2023, 1, 2, 19, 0, 0, 0, time.UTC)},
+		},
+
+		ExpectedOriginals:  []string{"a@", "b@"},
+		ExpectedDuplicates: map[string]string{"c@": "b@"},
+	})
+	validate(t, &testCase{
+		Name: "Unknown Duration",
+
+		Videos: []*model.Video{
+			{VideoID: "a", Title: " Minecraft Alles auf Anfang", PublishedAt: day(1)},
+			{VideoID: "b", Title: " Minecraft Alles auf Anfang", PublishedAt: day(2)},
+			{VideoID: "c", Title: " Minecraft Alles auf Anfang", PublishedAt: day(3)},
+		},
+		OriginalTitles: map[string]string{
+			"a": "Minecraft - Alles auf Anfang",
+			"b": "Minecraft - Alles auf Anfang",
+			"c": "Minecraft | Alles auf Anfang",
+		},
+
+		ExpectedOriginals:  []string{"a@", "c@"},
+		ExpectedDuplicates: map[string]string{"b@": "a@"},
+	})
+	validate(t, &testCase{
+		Name: "Unknown Duration Years Apart",
+
+		Videos: []*model.Video{
+			{VideoID: "a", Title: " Live-Stream", ChannelID: "main", PublishedAt: time.Date(2019, 12, 24, 19, 0, 0, 0, time.UTC)},
+			{VideoID: "b", Title: " Live-Stream", ChannelID: "main", PublishedAt: time.Date(2023, 12, 24, 19, 0, 0, 0, time.UTC)},
+		},
+		OriginalTitles: map[string]string{
+			"a": "Live-Stream",
+			"b": "Live-Stream",
+		},
+
+		ExpectedOriginals:  []string{"a@main", "b@main"},
+		ExpectedDuplicates: map[string]string{},
+	})
+	validate(t, &testCase{
+		Name: "Unknown Duration Mirror",
+
+		Videos: []*model.Video{
+			{VideoID: "a", Title: " Minecraft Alles auf Anfang", ChannelID: "main", PublishedAt: time.Date(2019, 1, 1, 19, 0, 0, 0, time.UTC)},
+			{VideoID: "b", Title: " Minecraft Alles auf Anfang", ChannelID: "mirror", PublishedAt: time.Date(2023, 1, 1, 19, 0, 0, 0, time.UTC)},
+		},
+		OriginalTitles: map[string]string{
+			"a": "Minecraft - Alles auf Anfang",
+			"b": "Minecraft - Alles auf Anfang",
+		},
+
+		ExpectedOriginals:  []string{"a@main"},
+		ExpectedDuplicates: map[string]string{"b@mirror": "a@main"},
+	})
+}
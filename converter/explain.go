@@ -0,0 +1,116 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/pkg/errors"
+)
+
+// Explainer defines a converter that can explain its conversion.
+type Explainer interface {
+	// Explanations returns how each video of the last conversion was mapped to a game.
+	Explanations() []*Explanation
+}
+
+// Explanation explains how a video was mapped to its game.
+type Explanation struct {
+	// VideoID is the unique identifier of the video.
+	VideoID string
+	// Title is the original video title.
+	Title string
+	// CleanedTitle is the video title after the cleanup.
+	CleanedTitle string
+	// DuplicateOf is the video ID of the original if the video is a re-upload or mirror, empty otherwise.
+	DuplicateOf string `json:",omitempty"`
+	// Specifier is the game specifier the video matched, empty if there was no match.
+	Specifier string `json:",omitempty"`
+	// MergedWith is the cleaned title of the earliest other video of the same game, empty if the video was not merged.
+	MergedWith string `json:",omitempty"`
+	// StoreLink is the store link the game was resolved from, empty if no store link was used.
+	StoreLink string `json:",omitempty"`
+	// Steam denotes if the game was resolved via Steam.
+	Steam bool
+	// Participants holds the co-players and guests detected in the title.
+	Participants []string `json:",omitempty"`
+	// Kind is the content kind detected in the title, empty if unknown.
+	Kind model.ContentKind `json:",omitempty"`
+	// Final denotes if the title marks the final episode of a series.
+	Final bool `json:",omitempty"`
+	// Episode is the episode parsed from the title, zero if unknown.
+	Episode model.Episode `json:",omitzero"`
+	// EpisodeTitle is the episode title split off with the learned title template of the channel, empty if none.
+	EpisodeTitle string `json:",omitempty"`
+	// Game is the name of the final game.
+	Game string
+}
+
+// ExplanationsJSONWrite writes explanations in JSON format.
+func ExplanationsJSONWrite(writer io.Writer, explanations []*Explanation) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(explanations); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// ExplanationsMarkdownWrite writes explanations as a Markdown table with the same fields as "ExplanationsJSONWrite".
+func ExplanationsMarkdownWrite(writer io.Writer, explanations []*Explanation) error {
+	var sb strings.Builder
+	sb.WriteString("| Video | Title | Cleaned Title | Duplicate Of | Specifier | Merged With | Store Link | Steam | Participants | Kind | Final | Episode | Episode Title | Game |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, e := range explanations {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			markdownCell(e.VideoID),
+			markdownCell(e.Title),
+			markdownCell(e.CleanedTitle),
+			markdownCell(e.DuplicateOf),
+			markdownCell(e.Specifier),
+			markdownCell(e.MergedWith),
+			markdownCell(e.StoreLink),
+			markdownFlag(e.Steam),
+			markdownCell(strings.Join(e.Participants, ", ")),
+			markdownCell(string(e.Kind)),
+			markdownFlag(e.Final),
+			markdownEpisode(e.Episode),
+			markdownCell(e.EpisodeTitle),
+			markdownCell(e.Game),
+		)
+	}
+
+	_, err := io.WriteString(writer, sb.String())
+	return errors.WithStack(err)
+}
+
+// markdownFlag returns "yes" for set flags and an empty cell otherwise.
+func markdownFlag(flag bool) string {
+	if flag {
+		return "yes"
+	}
+
+	return ""
+}
+
+// markdownEpisode returns the episode as e.g. "S2E3", or only its number if the season is unknown, and an empty cell if the episode is unknown.
+func markdownEpisode(episode model.Episode) string {
+	if episode.IsZero() {
+		return ""
+	} else if episode.Season == 0 {
+		return strconv.Itoa(episode.Number)
+	}
+
+	return fmt.Sprintf("S%dE%d", episode.Season, episode.Number)
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\n", " ", "\r", "")
+
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
@@ -0,0 +1,106 @@
+package converter
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bauersimon/grnkdb/metadata"
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExplanations(t *testing.T) {
+	videos := []*model.Video{
+		{
+			Title:       "Let's Play Minecraft #001 [Deutsch] [HD] - Alles auf Anfang",
+			PublishedAt: time.Date(2010, 10, 19, 19, 0, 17, 0, time.UTC),
+			VideoID:     "DM52HxaLK-Y",
+		},
+		{
+			Title:       "Let's Play Minecraft #002 [Deutsch] [HD] - Inselkoller & Nachtwache",
+			PublishedAt: time.Date(2010, 10, 20, 19, 0, 17, 0, time.UTC),
+			VideoID:     "tAaCTvht5Co",
+		},
+	}
+
+	converter := NewVideoToGameConverter(metadata.Chain{}, nil, ShortsInclude, 1, zaptest.NewLogger(t))
+	_, err := converter.Convert(videos)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*Explanation{
+		{
+			VideoID:      "DM52HxaLK-Y",
+			Title:        "Let's Play Minecraft #001 [Deutsch] [HD] - Alles auf Anfang",
+			CleanedTitle: " Minecraft Alles auf Anfang",
+			Specifier:    "minecraft",
+			MergedWith:   " Minecraft Inselkoller Nachtwache",
+			Kind:         model.KindLetsPlay,
+			Episode:      model.Episode{Number: 1},
+			Game:         "Minecraft",
+		},
+		{
+			VideoID:      "tAaCTvht5Co",
+			Title:        "Let's Play Minecraft #002 [Deutsch] [HD] - Inselkoller & Nachtwache",
+			CleanedTitle: " Minecraft Inselkoller Nachtwache",
+			Specifier:    "minecraft",
+			MergedWith:   " Minecraft Alles auf Anfang",
+			Kind:         model.KindLetsPlay,
+			Episode:      model.Episode{Number: 2},
+			Game:         "Minecraft",
+		},
+	}, converter.Explanations())
+
+	// Conversions do not share their explanations, and only the last one is kept.
+	var wg sync.WaitGroup
+	for _, n := range []int{1, 2, 1, 2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := converter.Convert(videos[:n])
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	_, err = converter.Convert(videos[:1])
+	require.NoError(t, err)
+	assert.Len(t, converter.Explanations(), 1)
+}
+
+func TestExplanationsMarkdownWrite(t *testing.T) {
+	var writer bytes.Buffer
+	require.NoError(t, ExplanationsMarkdownWrite(&writer, []*Explanation{
+		{
+			VideoID:      "XONCCUxHGxo",
+			Title:        "Der Mann mit dem Hut | INDIANA JONES #01",
+			CleanedTitle: "Der Mann mit dem Hut INDIANA JONES",
+			Specifier:    "indiana jones",
+			StoreLink:    "https://store.steampowered.com/app/2677660",
+			Steam:        true,
+			Participants: []string{"Sarazar", "Tobinator"},
+			Kind:         model.KindLetsPlay,
+			Episode:      model.Episode{Number: 1},
+			Game:         "Indiana Jones",
+		},
+		{
+			VideoID:      "a1b2c3d4e5f",
+			Title:        "Der Mann mit dem Hut | INDIANA JONES #01",
+			CleanedTitle: "Der Mann mit dem Hut INDIANA JONES",
+			DuplicateOf:  "XONCCUxHGxo",
+			Final:        true,
+			Episode:      model.Episode{Season: 2, Number: 1},
+			EpisodeTitle: "Der Mann mit dem Hut",
+			Game:         "Indiana Jones",
+		},
+	}))
+
+	assert.Equal(t, ""+
+		"| Video | Title | Cleaned Title | Duplicate Of | Specifier | Merged With | Store Link | Steam | Participants | Kind | Final | Episode | Episode Title | Game |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| XONCCUxHGxo | Der Mann mit dem Hut \\| INDIANA JONES #01 | Der Mann mit dem Hut INDIANA JONES |  | indiana jones |  | https://store.steampowered.com/app/2677660 | yes | Sarazar, Tobinator | letsplay |  | 1 |  | Indiana Jones |\n"+
+		"| a1b2c3d4e5f | Der Mann mit dem Hut \\| INDIANA JONES #01 | Der Mann mit dem Hut INDIANA JONES | XONCCUxHGxo |  |  |  |  |  |  | yes | S2E1 | Der Mann mit dem Hut | Indiana Jones |\n",
+		writer.String())
+}
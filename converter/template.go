@@ -0,0 +1,119 @@
+package converter
+
+import (
+	"cmp"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bauersimon/grnkdb/model"
+)
+
+// templateSeparatorRE matches the separators between the segments of a title, e.g. "Minecraft #01: Alles auf Anfang" or "Alles auf Anfang 🎮 Minecraft #01".
+var templateSeparatorRE = regexp.MustCompile(`\s+[|–—-]\s+|\s*\|\s*|:\s+|\s*[\p{So}\x{FE0F}\x{200D}]+\s*`)
+
+// minTemplateVideos is the minimum number of videos of a channel that have to follow a template for it to be learned.
+const minTemplateVideos = 3
+
+// titleTemplate is the pattern of the titles of a channel, i.e. the separator between the game segment holding the episode marker and the episode title.
+type titleTemplate struct {
+	// separator is the separator without surrounding whitespace.
+	separator string
+	// gameFirst denotes if the game segment comes before the episode title.
+	gameFirst bool
+}
+
+// learnTemplates infers the title templates of each channel from the titles with an episode marker, ordered from the most to the least common one.
+// Channels usually run multiple series with different templates, so every template followed by at least "minTemplateVideos" titles is learned.
+func learnTemplates(rules *Rules, videos []*model.Video) map[string][]*titleTemplate {
+	countsForChannel := map[string]map[titleTemplate]int{}
+	for _, video := range videos {
+		if rules.episode(video).IsZero() {
+			continue
+		}
+
+		counts := countsForChannel[video.ChannelID]
+		if counts == nil {
+			counts = map[titleTemplate]int{}
+			countsForChannel[video.ChannelID] = counts
+		}
+		separators := map[string]bool{}
+		for _, separator := range templateSeparatorRE.FindAllString(video.Title, -1) {
+			separators[strings.TrimSpace(separator)] = true
+		}
+		for separator := range separators {
+			for _, gameFirst := range []bool{true, false} {
+				template := titleTemplate{separator: separator, gameFirst: gameFirst}
+				if _, _, ok := template.split(rules, video); ok {
+					counts[template]++
+				}
+			}
+		}
+	}
+
+	templatesForChannel := map[string][]*titleTemplate{}
+	for channelID, counts := range countsForChannel {
+		for _, template := range slices.SortedFunc(maps.Keys(counts), func(a, b titleTemplate) int {
+			if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+				return c
+			} else if c := strings.Compare(a.separator, b.separator); c != 0 {
+				return c
+			} else if a.gameFirst == b.gameFirst {
+				return 0
+			} else if a.gameFirst {
+				return -1
+			}
+
+			return +1
+		}) {
+			if counts[template] >= minTemplateVideos {
+				templatesForChannel[channelID] = append(templatesForChannel[channelID], &template)
+			}
+		}
+	}
+
+	return templatesForChannel
+}
+
+// splitTitle splits the title of a video with the first fitting template.
+func splitTitle(rules *Rules, templates []*titleTemplate, video *model.Video) (game string, episodeTitle string, ok bool) {
+	for _, template := range templates {
+		if game, episodeTitle, ok := template.split(rules, video); ok {
+			return game, episodeTitle, true
+		}
+	}
+
+	return "", "", false
+}
+
+// split splits the title of a video into its game segment and its episode title.
+// The game segment is the one holding the episode marker, before the first or after the last such separator.
+func (t *titleTemplate) split(rules *Rules, video *model.Video) (game string, episodeTitle string, ok bool) {
+	matches := templateSeparatorRE.FindAllStringIndex(video.Title, -1)
+	if !t.gameFirst {
+		slices.Reverse(matches)
+	}
+
+	for _, match := range matches {
+		if strings.TrimSpace(video.Title[match[0]:match[1]]) != t.separator {
+			continue
+		}
+
+		if t.gameFirst {
+			game, episodeTitle = video.Title[:match[0]], video.Title[match[1]:]
+		} else {
+			episodeTitle, game = video.Title[:match[0]], video.Title[match[1]:]
+		}
+		game, episodeTitle = strings.TrimSpace(game), strings.TrimSpace(episodeTitle)
+		if game == "" || episodeTitle == "" {
+			continue
+		} else if rules.episode(&model.Video{Title: game, ChannelID: video.ChannelID}).IsZero() {
+			continue
+		}
+
+		return game, episodeTitle, true
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,130 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTitleTemplates(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Titles []string
+
+		// ExpectedGames holds the game segment of each title, empty if no template fits.
+		ExpectedGames []string
+		// ExpectedEpisodeTitles holds the episode title of each title, empty if no template fits.
+		ExpectedEpisodeTitles []string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			rules := DefaultRules()
+			videos := make([]*model.Video, len(tc.Titles))
+			for i, title := range tc.Titles {
+				videos[i] = &model.Video{Title: title}
+			}
+
+			templates := learnTemplates(rules, videos)
+
+			actualGames := make([]string, len(videos))
+			actualEpisodeTitles := make([]string, len(videos))
+			for i, video := range videos {
+				actualGames[i], actualEpisodeTitles[i], _ = splitTitle(rules, templates[video.ChannelID], video)
+			}
+			assert.Equal(t, tc.ExpectedGames, actualGames)
+			assert.Equal(t, tc.ExpectedEpisodeTitles, actualEpisodeTitles)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Game First",
+
+		Titles: []string{
+			"Minecraft #01: Alles auf Anfang",
+			"Minecraft #02: Inselkoller & Nachtwache",
+			"Minecraft #03: Die Mine",
+		},
+
+		ExpectedGames:         []string{"Minecraft #01", "Minecraft #02", "Minecraft #03"},
+		ExpectedEpisodeTitles: []string{"Alles auf Anfang", "Inselkoller & Nachtwache", "Die Mine"},
+	})
+
+	validate(t, &testCase{
+		Name: "Game Last",
+
+		Titles: []string{
+			"Letzte Höhle 🎮 INDIANA JONES AND THE GREAT CIRCLE #01",
+			"Große Schatz um Mitternacht 🎮 INDIANA JONES AND THE GREAT CIRCLE #02",
+			"Blaue Festung im Anmarsch 🎮 INDIANA JONES AND THE GREAT CIRCLE #03",
+		},
+
+		ExpectedGames: []string{
+			"INDIANA JONES AND THE GREAT CIRCLE #01",
+			"INDIANA JONES AND THE GREAT CIRCLE #02",
+			"INDIANA JONES AND THE GREAT CIRCLE #03",
+		},
+		ExpectedEpisodeTitles: []string{"Letzte Höhle", "Große Schatz um Mitternacht", "Blaue Festung im Anmarsch"},
+	})
+
+	validate(t, &testCase{
+		Name: "Separator Within Game",
+
+		Titles: []string{
+			"Amnesia: The Dark Descent #1: Erwachen",
+			"Amnesia: The Dark Descent #2: Der Keller",
+			"Amnesia: The Dark Descent #3: Schatten",
+		},
+
+		ExpectedGames: []string{
+			"Amnesia: The Dark Descent #1",
+			"Amnesia: The Dark Descent #2",
+			"Amnesia: The Dark Descent #3",
+		},
+		ExpectedEpisodeTitles: []string{"Erwachen", "Der Keller", "Schatten"},
+	})
+
+	validate(t, &testCase{
+		Name: "Multiple Series",
+
+		Titles: []string{
+			"Minecraft #01: Alles auf Anfang",
+			"Minecraft #02: Inselkoller",
+			"Minecraft #03: Die Mine",
+			"Letzte Höhle 🎮 SUBNAUTICA #01",
+			"Große Schatz 🎮 SUBNAUTICA #02",
+			"Blaue Festung 🎮 SUBNAUTICA #03",
+		},
+
+		ExpectedGames:         []string{"Minecraft #01", "Minecraft #02", "Minecraft #03", "SUBNAUTICA #01", "SUBNAUTICA #02", "SUBNAUTICA #03"},
+		ExpectedEpisodeTitles: []string{"Alles auf Anfang", "Inselkoller", "Die Mine", "Letzte Höhle", "Große Schatz", "Blaue Festung"},
+	})
+
+	validate(t, &testCase{
+		Name: "Too Few Videos",
+
+		Titles: []string{
+			"Minecraft #01: Alles auf Anfang",
+			"Minecraft #02: Inselkoller",
+		},
+
+		ExpectedGames:         []string{"", ""},
+		ExpectedEpisodeTitles: []string{"", ""},
+	})
+
+	validate(t, &testCase{
+		Name: "No Episode Marker",
+
+		Titles: []string{
+			"Minecraft #01: Alles auf Anfang",
+			"Minecraft #02: Inselkoller",
+			"Minecraft #03: Die Mine",
+			"Minecraft: Der große Rückblick",
+		},
+
+		ExpectedGames:         []string{"Minecraft #01", "Minecraft #02", "Minecraft #03", ""},
+		ExpectedEpisodeTitles: []string{"Alles auf Anfang", "Inselkoller", "Die Mine", ""},
+	})
+}
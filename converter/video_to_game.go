@@ -2,35 +2,73 @@ package converter
 
 import (
 	"maps"
-	"regexp"
 	"slices"
 	"strings"
+	"sync"
 
+	"github.com/bauersimon/grnkdb/metadata"
 	"github.com/bauersimon/grnkdb/model"
-	"github.com/bauersimon/grnkdb/steam"
 	"github.com/bauersimon/grnkdb/util"
 	"go.uber.org/zap"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
-var steamStoreLinkRE = regexp.MustCompile(`steampowered\.com\/app\/(\d+)`)
-
 // VideoToGameConverter converts video metadata to game information.
 type VideoToGameConverter struct {
-	steamClient *steam.Client
-	windowSize  uint
-	logger      *zap.Logger
+	metadataProvider metadata.Provider
+	rules            *Rules
+	shorts           ShortsPolicy
+	workers          uint
+	logger           *zap.Logger
+
+	// mutex guards the explanations, as conversions may run concurrently.
+	mutex sync.Mutex
+	// explanations holds the explanations of the last finished conversion in input order.
+	explanations []*Explanation
+}
+
+// conversion holds the state of a single conversion.
+type conversion struct {
+	*VideoToGameConverter
+
+	// explanationForVideo holds the explanations by cleaned video.
+	explanationForVideo map[*model.Video]*Explanation
 }
 
 var _ Interface = (*VideoToGameConverter)(nil)
+var _ Explainer = (*VideoToGameConverter)(nil)
+
+// ShortsPolicy defines how YouTube Shorts and clips are converted.
+type ShortsPolicy string
+
+const (
+	// ShortsInclude converts Shorts like all other videos.
+	ShortsInclude ShortsPolicy = "include"
+	// ShortsExclude drops Shorts.
+	ShortsExclude ShortsPolicy = "exclude"
+	// ShortsKnown attaches Shorts only to games identified from other videos and drops all others.
+	ShortsKnown ShortsPolicy = "known"
+)
 
 // NewVideoToGameConverter creates a new video-to-game converter.
-func NewVideoToGameConverter(steamClient *steam.Client, windowSize uint, logger *zap.Logger) *VideoToGameConverter {
+// The metadata provider is queried by the given number of concurrent workers and must be safe for concurrent use.
+// Titles are cleaned up with the given rules, or the default rules if "nil".
+// Shorts are converted according to the given policy, or like all other videos if empty.
+func NewVideoToGameConverter(metadataProvider metadata.Provider, rules *Rules, shorts ShortsPolicy, workers uint, logger *zap.Logger) *VideoToGameConverter {
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	if shorts == "" {
+		shorts = ShortsInclude
+	}
+
 	return &VideoToGameConverter{
-		steamClient: steamClient,
-		windowSize:  windowSize,
-		logger:      logger,
+		metadataProvider: metadataProvider,
+		rules:            rules,
+		shorts:           shorts,
+		workers:          workers,
+		logger:           logger,
 	}
 }
 
@@ -43,6 +81,9 @@ func (c *VideoToGameConverter) Convert(videos []*model.Video) (games []*model.Ga
 			VideoID:     video.VideoID,
 			Title:       video.Title,
 			Description: video.Description,
+			Duration:    video.Duration,
+			Short:       video.IsShort(),
+			Playlist:    video.Playlist,
 			Link:        video.Link,
 			PublishedAt: video.PublishedAt,
 			ChannelID:   video.ChannelID,
@@ -50,156 +91,553 @@ func (c *VideoToGameConverter) Convert(videos []*model.Video) (games []*model.Ga
 		}
 	}
 
+	// Learn the title templates of the channels so the game segment can be cut out of the titles before clustering.
+	templates := learnTemplates(c.rules, cleanedVideos)
+	for channelID, channelTemplates := range templates {
+		for _, template := range channelTemplates {
+			c.logger.Debug("learned title template",
+				zap.String("channel", channelID),
+				zap.String("separator", template.separator),
+				zap.Bool("gameFirst", template.gameFirst))
+		}
+	}
+
 	c.logger.Debug("cleaning up video meta")
-	cleanupVideoMeta(cleanedVideos)
+	annotations := make([]*annotation, len(cleanedVideos))
+	util.Parallel(len(cleanedVideos), c.workers, func(i int) {
+		participants, coOp := c.rules.participants(cleanedVideos[i])
+		annotations[i] = &annotation{
+			participants: participants,
+			coOp:         coOp,
+			kind:         c.rules.kind(cleanedVideos[i]),
+			final:        c.rules.isFinal(cleanedVideos[i]),
+		}
+		if cleanedVideos[i].Short {
+			annotations[i].kind = model.KindClip
+		}
+		cleanedVideos[i].Episode = c.rules.episode(cleanedVideos[i])
+		if game, episodeTitle, ok := splitTitle(c.rules, templates[cleanedVideos[i].ChannelID], cleanedVideos[i]); ok {
+			cleanedVideos[i].Title = game
+			cleanedVideos[i].EpisodeTitle = episodeTitle
+		}
+		c.rules.cleanupVideoMeta(cleanedVideos[i : i+1])
+	})
+	annotationForVideo := make(map[*model.Video]*annotation, len(cleanedVideos))
+	for i, video := range cleanedVideos {
+		annotationForVideo[video] = annotations[i]
+	}
 
-	c.logger.Info("converting videos to games", zap.Int("videos", len(videos)))
-	for window := range util.SlidingWindowed(cleanedVideos, c.windowSize, max(uint(0), c.windowSize/2)) {
-		g, err := c.convertVideosToGames(window)
-		if err != nil {
-			return nil, err
+	cv := &conversion{
+		VideoToGameConverter: c,
+		explanationForVideo:  make(map[*model.Video]*Explanation, len(videos)),
+	}
+	explanations := make([]*Explanation, len(videos))
+	for i, video := range cleanedVideos {
+		explanation := &Explanation{
+			VideoID:      video.VideoID,
+			Title:        videos[i].Title,
+			CleanedTitle: video.Title,
+			Participants: annotationForVideo[video].participants,
+			Kind:         annotationForVideo[video].kind,
+			Final:        annotationForVideo[video].final,
+			Episode:      video.Episode,
+			EpisodeTitle: video.EpisodeTitle,
 		}
-		games = model.MergeGames(games, g)
+		cv.explanationForVideo[video] = explanation
+		explanations[i] = explanation
 	}
 
+	// Drop re-uploads and mirrors so only the original of each video is converted.
+	originalTitle := make(map[*model.Video]string, len(videos))
+	for i, video := range cleanedVideos {
+		originalTitle[video] = videos[i].Title
+	}
+	cleanedVideos, originalForDuplicate := deduplicateVideos(cleanedVideos, originalTitle)
+	for duplicate, original := range originalForDuplicate {
+		cv.explanation(duplicate).DuplicateOf = original.VideoID
+	}
+	if len(originalForDuplicate) > 0 {
+		c.logger.Info("dropped duplicate videos", zap.Int("duplicates", len(originalForDuplicate)))
+	}
+
+	// Process videos in publish order per channel so consecutive episodes of a series are neighbors, independent of the input order.
+	slices.SortStableFunc(cleanedVideos, compareVideosByChannel)
+
+	// Keep Shorts out of the clustering unless they are converted like all other videos, as their titles rarely name the game.
+	var shorts []*model.Video
+	if c.shorts != ShortsInclude {
+		cleanedVideos, shorts = partitionShorts(cleanedVideos)
+		if c.shorts == ShortsExclude {
+			c.logger.Info("dropped shorts", zap.Int("shorts", len(shorts)))
+			shorts = nil
+		}
+	}
+
+	c.logger.Info("converting videos to games", zap.Int("videos", len(cleanedVideos)), zap.Int("shorts", len(shorts)))
+
+	games, err = cv.convertVideosToGames(cleanedVideos, shorts, annotationForVideo)
+	if err != nil {
+		return nil, err
+	}
+	for duplicate, original := range originalForDuplicate {
+		cv.explanation(duplicate).Game = cv.explanation(original).Game
+	}
+
+	c.mutex.Lock()
+	c.explanations = explanations
+	c.mutex.Unlock()
+
 	return games, nil
 }
 
-// convertVideosToGames converts model.Video structs to games
-func (c *VideoToGameConverter) convertVideosToGames(videos []*model.Video) (games []*model.Game, err error) {
-	earliestVideoForGame := map[string]*model.Video{}
-	for i, video := range videos {
-		c.logger.Debug("extracting game information",
-			zap.String("video", video.VideoID),
-			zap.Int("progress", i+1),
-			zap.Int("total", len(videos)))
-		var newGameSpecifier string
-
-		// Try to extract Steam links from description.
-		if matches := steamStoreLinkRE.FindStringSubmatch(video.Description); len(matches) > 0 {
-			name, err := c.steamClient.GameName(matches[1])
-			if err != nil {
-				c.logger.Error("cannot get name from steam",
-					zap.String("video", video.VideoID),
-					zap.Error(err))
-			} else {
-				newGameSpecifier = strings.ToLower(name)
-				c.logger.Debug("found game information on steam",
-					zap.String("video", video.VideoID),
-					zap.String("game", name))
-			}
-		}
+// annotation holds the information extracted from the title of a video before its cleanup.
+type annotation struct {
+	// participants holds the names of the co-players and guests.
+	participants []string
+	// coOp denotes if the video was played together with others.
+	coOp bool
+	// kind is the content kind, empty if unknown.
+	kind model.ContentKind
+	// final denotes if the video is marked as the final episode of a series.
+	final bool
+}
 
-		// Brute force try to find similar pre- or suffixes among all found games.
-		var oldGameSpecifier string
-		if newGameSpecifier == "" {
-			for preSuffix := range maps.Keys(earliestVideoForGame) {
-				newPrefix := longestCommonPrefix(
-					strings.ToLower(video.Title),
-					strings.ToLower(preSuffix),
-				)
-				newSuffix := longestCommonSuffix(
-					strings.ToLower(video.Title),
-					strings.ToLower(preSuffix),
-				)
-				if commonWords[strings.ToLower(strings.TrimSpace(newPrefix))] {
-					newPrefix = ""
-				}
-				if commonWords[strings.ToLower(strings.TrimSpace(newSuffix))] {
-					newSuffix = ""
-				}
+// partitionShorts splits videos into regular videos and Shorts, keeping their order.
+func partitionShorts(videos []*model.Video) (regular []*model.Video, shorts []*model.Video) {
+	for _, video := range videos {
+		if video.Short {
+			shorts = append(shorts, video)
+		} else {
+			regular = append(regular, video)
+		}
+	}
 
-				if len(strings.TrimSpace(newPrefix)) > 2 &&
-					len(newPrefix) > len(newGameSpecifier) &&
-					len(newPrefix) > len(newSuffix) {
+	return regular, shorts
+}
 
-					newGameSpecifier = newPrefix
-					oldGameSpecifier = preSuffix
-				} else if len(strings.TrimSpace(newSuffix)) > 2 &&
-					len(newSuffix) > len(newGameSpecifier) {
+// convertVideosToGames converts model.Video structs to games.
+// Shorts are not clustered but only attached to the games of the other videos, and dropped if they match none.
+func (c *conversion) convertVideosToGames(videos []*model.Video, shorts []*model.Video, annotationForVideo map[*model.Video]*annotation) (games []*model.Game, err error) {
+	steamForGame := map[string]*model.SteamMetadata{}
+	steamNameForGame := map[string]string{}
+	specifierForVideo := map[*model.Video]string{}
+	resolvedVideos := map[*model.Video]bool{}
+	for i, game := range c.resolveGames(videos) {
+		video := videos[i]
+		explanation := c.explanation(video)
 
-					newGameSpecifier = newSuffix
-					oldGameSpecifier = preSuffix
+		if game != nil {
+			specifier := strings.ToLower(game.Name)
+			specifierForVideo[video] = specifier
+			resolvedVideos[video] = true
+			if game.Steam != nil && steamForGame[specifier] == nil {
+				steamForGame[specifier] = game.Steam
+				if !game.NameFromSlug { // Names from slugs are only used if no title of the videos names the game.
+					steamNameForGame[specifier] = game.Name
 				}
 			}
+			explanation.StoreLink = game.Link
+			explanation.Steam = game.Steam != nil
+			c.logger.Debug("found game information in store link",
+				zap.String("video", video.VideoID),
+				zap.String("link", game.Link),
+				zap.String("game", game.Name))
 		}
+	}
 
-		if newGameSpecifier != "" {
-			if oldGameSpecifier != "" && newGameSpecifier != oldGameSpecifier { // Shorten the specifier.
-				earliestVideo := earliestVideoForGame[oldGameSpecifier]
-				delete(earliestVideoForGame, oldGameSpecifier)
-				earliestVideoForGame[newGameSpecifier] = earliestVideo
-			}
-			if earlierVideo := earliestVideoForGame[newGameSpecifier]; earlierVideo != nil {
-				if compareVideos(video, earlierVideo) < 0 { // Found earlier video.
-					earliestVideoForGame[newGameSpecifier] = video
-				}
-			} else {
-				earliestVideoForGame[newGameSpecifier] = video
+	// Cluster all remaining videos at once over the shared pre- and suffixes of their titles.
+	c.logger.Debug("clustering videos")
+	clusterVideos(videos, specifierForVideo, c.rules)
+
+	videosForGame := map[string][]*model.Video{}
+	steamForKey := map[string]*model.SteamMetadata{}
+	steamNameForKey := map[string]string{}
+	formCountForKey := map[string]map[string]int{}
+	for _, video := range videos {
+		specifier := specifierForVideo[video]
+		key := strings.ToLower(trimSpecifier(specifier))
+		videosForGame[key] = append(videosForGame[key], video)
+		if steamForKey[key] == nil && steamForGame[specifier] != nil {
+			steamForKey[key] = steamForGame[specifier]
+			steamNameForKey[key] = steamNameForGame[specifier]
+		}
+		if form, ok := originalForm(video.Title, specifier); ok {
+			if formCountForKey[key] == nil {
+				formCountForKey[key] = map[string]int{}
 			}
+			formCountForKey[key][form]++
+		}
+
+		explanation := c.explanation(video)
+		if specifier != video.Title {
+			explanation.Specifier = specifier
 			c.logger.Debug("match", zap.String("video", video.Title))
 		} else {
-			earliestVideoForGame[video.Title] = video
 			c.logger.Debug("no match", zap.String("video", video.Title))
 		}
 	}
 
-	caser := cases.Title(language.German)
-	for title, video := range earliestVideoForGame {
+	if len(shorts) > 0 {
+		var attached int
+		for i, key := range c.knownGamesOfShorts(shorts, videosForGame) {
+			if key == "" {
+				c.logger.Debug("dropped short", zap.String("video", shorts[i].Title))
+
+				continue
+			}
+			videosForGame[key] = append(videosForGame[key], shorts[i])
+			c.explanation(shorts[i]).Specifier = key
+			attached++
+		}
+		c.logger.Info("attached shorts to known games", zap.Int("attached", attached), zap.Int("dropped", len(shorts)-attached))
+	}
+
+	// Games with the same identifier, e.g. names differing only in punctuation, are the same game.
+	keyForID := map[string]string{}
+	for _, key := range slices.Sorted(maps.Keys(videosForGame)) {
+		id := model.NewGameID(gameName(key, steamNameForKey[key], formCountForKey[key]), steamForKey[key])
+		first, ok := keyForID[id]
+		if !ok {
+			keyForID[id] = key
+
+			continue
+		}
+
+		videosForGame[first] = append(videosForGame[first], videosForGame[key]...)
+		if formCountForKey[first] == nil {
+			formCountForKey[first] = map[string]int{}
+		}
+		for form, count := range formCountForKey[key] {
+			formCountForKey[first][form] += count
+		}
+		delete(videosForGame, key)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(videosForGame)) {
+		videos := videosForGame[key]
+		name := gameName(key, steamNameForKey[key], formCountForKey[key])
+		for _, video := range videos {
+			c.explanation(video).Game = name
+		}
+		if len(videos) > 1 {
+			ordered := slices.SortedStableFunc(slices.Values(videos), compareVideos)
+			for _, video := range videos {
+				if video != ordered[0] {
+					c.explanation(video).MergedWith = ordered[0].Title
+				} else {
+					c.explanation(video).MergedWith = ordered[1].Title
+				}
+			}
+		}
+
+		evidence := &gameEvidence{
+			steam:  steamForKey[key] != nil,
+			videos: videos,
+			key:    key,
+		}
+		for _, video := range videos {
+			evidence.storeLink = evidence.storeLink || resolvedVideos[video]
+		}
+
 		games = append(games, &model.Game{
-			Name: caser.String(strings.TrimSpace(strings.Trim(title, "-:\" \t"))),
-			Content: []*model.Content{
-				{
-					Link:   video.Link,
-					Start:  video.PublishedAt,
-					Source: video.Source,
-				},
-			},
+			ID:         model.NewGameID(name, steamForKey[key]),
+			Name:       name,
+			Content:    contentOfVideos(videos, annotationForVideo),
+			Steam:      steamForKey[key],
+			Confidence: evidence.confidence(),
 		})
 	}
-	slices.SortFunc(games, func(a, b *model.Game) int {
-		return strings.Compare(a.Name, b.Name)
-	})
 
 	return games, nil
 }
 
-func compareVideos(a, b *model.Video) int {
-	if a.PublishedAt.Before(b.PublishedAt) {
-		return -1
-	} else if b.PublishedAt.Before(a.PublishedAt) {
-		return +1
+// knownGamesOfShorts returns the key of the known game of each Short at the same index, or an empty string if it matches no known game.
+// A Short matches the game its store link resolves to, or otherwise the game with the longest key which is a token prefix or suffix of its title.
+func (c *conversion) knownGamesOfShorts(shorts []*model.Video, videosForGame map[string][]*model.Video) []string {
+	keys := make([]string, len(shorts))
+	for i, game := range c.resolveGames(shorts) {
+		if game != nil {
+			if key := strings.ToLower(trimSpecifier(game.Name)); videosForGame[key] != nil {
+				keys[i] = key
+				c.explanation(shorts[i]).StoreLink = game.Link
+				c.explanation(shorts[i]).Steam = game.Steam != nil
+
+				continue
+			}
+		}
+
+		tokens := strings.Fields(strings.ToLower(shorts[i].Title))
+		for n := len(tokens); n > 0 && keys[i] == ""; n-- {
+			for _, candidate := range []string{strings.Join(tokens[:n], " "), strings.Join(tokens[len(tokens)-n:], " ")} {
+				if key := trimSpecifier(candidate); key != "" && videosForGame[key] != nil {
+					keys[i] = key
+
+					break
+				}
+			}
+		}
 	}
 
-	return 0
+	return keys
 }
 
-// longestCommonPrefix finds the longest common prefix string amongst two input strings.
-func longestCommonPrefix(str1, str2 string) string {
-	minLen := min(len(str1), len(str2))
+// trimSpecifier removes surrounding separators and whitespace from a specifier.
+func trimSpecifier(specifier string) string {
+	return strings.TrimSpace(strings.Trim(specifier, "-:\" \t"))
+}
 
-	i := 0
-	for i < minLen {
-		if str1[i] == str2[i] {
-			i++
+// originalForm returns the original-cased form of a specifier within a title, i.e. the token prefix or suffix of the title matching the specifier.
+func originalForm(title string, specifier string) (form string, ok bool) {
+	tokens := strings.Fields(title)
+	specifier = strings.ToLower(strings.Join(strings.Fields(specifier), " "))
+	n := len(strings.Fields(specifier))
+	if n == 0 || n > len(tokens) {
+		return "", false
+	}
+
+	if prefix := strings.Join(tokens[:n], " "); strings.ToLower(prefix) == specifier {
+		form = prefix
+	} else if suffix := strings.Join(tokens[len(tokens)-n:], " "); strings.ToLower(suffix) == specifier {
+		form = suffix
+	} else {
+		return "", false
+	}
+	form = trimSpecifier(form)
+
+	return form, form != ""
+}
+
+var gameNameCaser = cases.Title(language.German)
+
+// gameName returns the display name of a game.
+// The official Steam name is used verbatim if known, otherwise the most common original-cased form of the titles.
+// The lowercased key is only title-cased if there is no such form or the form is not reliable, i.e. entirely lowercase or shouted in uppercase over more than two words.
+func gameName(key string, steamName string, formCount map[string]int) string {
+	if steamName != "" {
+		return steamName
+	}
+
+	var name string
+	for _, form := range slices.Sorted(maps.Keys(formCount)) {
+		if name == "" || formCount[form] > formCount[name] {
+			name = form
+		}
+	}
+	isShouted := name == strings.ToUpper(name) && len(strings.Fields(name)) > 2
+	if name != "" && name != strings.ToLower(name) && !isShouted {
+		return name
+	}
+
+	return gameNameCaser.String(key)
+}
+
+// resolveGames tries to resolve the games of the videos from store links in their descriptions concurrently.
+// The result holds the game of each video at the same index, or "nil" if it could not be resolved.
+func (c *VideoToGameConverter) resolveGames(videos []*model.Video) []*metadata.Game {
+	games := make([]*metadata.Game, len(videos))
+	util.Parallel(len(videos), c.workers, func(i int) {
+		video := videos[i]
+		c.logger.Debug("extracting game information",
+			zap.String("video", video.VideoID),
+			zap.Int("progress", i+1),
+			zap.Int("total", len(videos)))
+
+		game, err := c.metadataProvider.Game(video.Description)
+		if err != nil {
+			c.logger.Error("cannot resolve game metadata",
+				zap.String("video", video.VideoID),
+				zap.Error(err))
+
+			return
+		}
+		games[i] = game
+	})
+
+	return games
+}
+
+// explanation returns the explanation record of a cleaned video.
+func (c *conversion) explanation(video *model.Video) *Explanation {
+	explanation := c.explanationForVideo[video]
+	if explanation == nil { // Video was not passed through "Convert".
+		explanation = &Explanation{
+			VideoID:      video.VideoID,
+			CleanedTitle: video.Title,
+		}
+		c.explanationForVideo[video] = explanation
+	}
+
+	return explanation
+}
+
+// Explanations returns how each video of the last finished conversion was mapped to a game, in input order.
+func (c *VideoToGameConverter) Explanations() []*Explanation {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.explanations
+}
+
+// contentOfVideos summarizes the episodes of a game per source, content kind and run.
+func contentOfVideos(videos []*model.Video, annotationForVideo map[*model.Video]*annotation) (content []*model.Content) {
+	kind := func(video *model.Video) model.ContentKind {
+		if annotation := annotationForVideo[video]; annotation != nil {
+			return annotation.kind
+		}
+
+		return ""
+	}
+
+	videos = slices.Clone(videos)
+	slices.SortStableFunc(videos, func(a, b *model.Video) int {
+		if c := strings.Compare(string(a.Source), string(b.Source)); c != 0 {
+			return c
+		} else if c := strings.Compare(string(kind(a)), string(kind(b))); c != 0 {
+			return c
+		}
+
+		return compareVideos(a, b)
+	})
+	videos = slices.CompactFunc(videos, func(a, b *model.Video) bool {
+		return a == b
+	})
+
+	var run []*model.Video
+	var previous *model.Content
+	for i, video := range videos {
+		run = append(run, video)
+		if i+1 < len(videos) {
+			next := videos[i+1]
+			if next.Source == video.Source && kind(next) == kind(video) && !isNewRun(run, next) {
+				continue
+			}
+		}
+
+		current := contentOfRun(run, annotationForVideo)
+		current.Kind = kind(video)
+		if previous != nil && previous.Source == current.Source && previous.Kind == current.Kind {
+			current.Run = previous.Run + 1
+		}
+		content = append(content, current)
+		previous = current
+		run = nil
+	}
+
+	return content
+}
+
+// isNewRun checks if a video starts a new run of a series, i.e. a re-run, or a long break or playlist change without continued episode numbering.
+func isNewRun(run []*model.Video, video *model.Video) bool {
+	if isRerun(run, video) {
+		return true
+	}
+
+	last := run[len(run)-1]
+	if !last.Episode.IsZero() && !video.Episode.IsZero() && video.Episode.Compare(last.Episode) > 0 {
+		return false
+	}
+
+	return video.PublishedAt.Sub(last.PublishedAt) >= model.RunGap ||
+		(last.Playlist != "" && video.Playlist != "" && last.Playlist != video.Playlist)
+}
+
+// isRerun checks if a video starts a re-run of a series, i.e. it is the first episode again.
+func isRerun(run []*model.Video, video *model.Video) bool {
+	if video.Episode.Number != 1 {
+		return false
+	}
+
+	return slices.ContainsFunc(run, func(v *model.Video) bool {
+		return v.Episode.Compare(video.Episode) == 0
+	})
+}
+
+// contentOfRun summarizes the episodes of a single run of a series in publish order.
+// The run starts with its first episode if known, and otherwise with its earliest video.
+// Content is co-op if any of its videos is, and its participants are those of all its videos.
+// Content is completed with its latest video that is marked as final.
+func contentOfRun(videos []*model.Video, annotationForVideo map[*model.Video]*annotation) *model.Content {
+	content := &model.Content{
+		Link:   videos[0].Link,
+		Start:  videos[0].PublishedAt,
+		Source: videos[0].Source,
+	}
+
+	durationKnown := true
+	var first *model.Video
+	numbers := map[int]map[int]bool{}
+	for _, video := range videos {
+		content.End = video.PublishedAt
+		content.Episodes++
+		if annotation := annotationForVideo[video]; annotation != nil {
+			content.CoOp = content.CoOp || annotation.coOp
+			for _, participant := range annotation.participants {
+				if !slices.Contains(content.Participants, participant) {
+					content.Participants = append(content.Participants, participant)
+				}
+			}
+			if annotation.final {
+				content.Completed = true
+				content.CompletedAt = video.PublishedAt
+			}
+		}
+		content.VideoIDs = append(content.VideoIDs, video.VideoID)
+		if video.Duration == 0 {
+			durationKnown = false
+		}
+		if durationKnown {
+			content.Duration += video.Duration
 		} else {
-			i = max(0, i-1)
-			break
+			content.Duration = 0
+		}
+
+		if !video.Episode.IsZero() {
+			if first == nil || video.Episode.Compare(first.Episode) < 0 {
+				first = video
+			}
+			season := max(video.Episode.Season, 1)
+			if numbers[season] == nil {
+				numbers[season] = map[int]bool{}
+			}
+			numbers[season][video.Episode.Number] = true
+		}
+	}
+	slices.Sort(content.Participants)
+
+	if first != nil && first.Episode.Number == 1 {
+		content.Start = first.PublishedAt
+		content.Link = first.Link
+	}
+	for _, seasonNumbers := range numbers {
+		if len(seasonNumbers) < 2 {
+			continue
+		}
+		last := slices.Max(slices.Collect(maps.Keys(seasonNumbers)))
+		for number := 1; number < last; number++ {
+			if !seasonNumbers[number] {
+				content.MissingEpisodes++
+			}
 		}
 	}
 
-	return str1[:i]
+	return content
 }
 
-// longestCommonSuffix finds the longest common suffix string amongst two input strings.
-func longestCommonSuffix(str1, str2 string) string {
-	return reverseString(longestCommonPrefix(reverseString(str1), reverseString(str2)))
+// compareVideosByChannel orders videos by channel first and then by publish date.
+func compareVideosByChannel(a, b *model.Video) int {
+	if c := strings.Compare(a.ChannelID, b.ChannelID); c != 0 {
+		return c
+	}
+
+	return compareVideos(a, b)
 }
 
-func reverseString(s string) string {
-	runes := []rune(s)
-	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
-		runes[i], runes[j] = runes[j], runes[i]
+func compareVideos(a, b *model.Video) int {
+	if a.PublishedAt.Before(b.PublishedAt) {
+		return -1
+	} else if b.PublishedAt.Before(a.PublishedAt) {
+		return +1
 	}
-	return string(runes)
+
+	return strings.Compare(a.VideoID, b.VideoID)
 }
@@ -1,13 +1,23 @@
 package converter
 
 import (
+	"bytes"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/bauersimon/grnkdb/metadata"
+	mockMetadata "github.com/bauersimon/grnkdb/mocks/github.com/bauersimon/grnkdb/metadata"
 	"github.com/bauersimon/grnkdb/model"
-	"github.com/bauersimon/grnkdb/steam"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -15,6 +25,8 @@ func TestConvertVideosToGames(t *testing.T) {
 	type testCase struct {
 		Name string
 
+		Setup  func(provider *mockMetadata.MockProvider)
+		Shorts ShortsPolicy
 		Videos []*model.Video
 
 		Expected []*model.Game
@@ -24,8 +36,14 @@ func TestConvertVideosToGames(t *testing.T) {
 	validate := func(t *testing.T, tc *testCase) {
 		t.Run(tc.Name, func(t *testing.T) {
 			logger := zaptest.NewLogger(t)
+			provider := mockMetadata.NewMockProvider(t)
+			if tc.Setup != nil {
+				tc.Setup(provider)
+			} else {
+				provider.EXPECT().Game(mock.Anything).Return(nil, nil)
+			}
 
-			converter := NewVideoToGameConverter(steam.NewClient(), 100, logger)
+			converter := NewVideoToGameConverter(provider, nil, tc.Shorts, 1, logger)
 			actual, err := converter.Convert(tc.Videos)
 			if tc.Error != "" {
 				assert.ErrorContains(t, err, tc.Error)
@@ -45,6 +63,7 @@ func TestConvertVideosToGames(t *testing.T) {
 				PublishedAt: time.Date(2010, 10, 19, 19, 0, 17, 0, time.UTC),
 				VideoID:     "DM52HxaLK-Y",
 				Link:        "https://www.youtube.com/watch?v=DM52HxaLK-Y",
+				Duration:    model.Duration(20 * time.Minute),
 				Source:      model.SourceYouTube,
 			},
 			{
@@ -52,6 +71,7 @@ func TestConvertVideosToGames(t *testing.T) {
 				PublishedAt: time.Date(2010, 10, 20, 19, 0, 17, 0, time.UTC),
 				VideoID:     "tAaCTvht5Co",
 				Link:        "https://www.youtube.com/watch?v=tAaCTvht5Co",
+				Duration:    model.Duration(25 * time.Minute),
 				Source:      model.SourceYouTube,
 			},
 			{
@@ -59,20 +79,29 @@ func TestConvertVideosToGames(t *testing.T) {
 				PublishedAt: time.Date(2010, 10, 21, 19, 0, 17, 0, time.UTC),
 				VideoID:     "ednqMErMOsM",
 				Link:        "https://www.youtube.com/watch?v=ednqMErMOsM",
+				Duration:    model.Duration(25 * time.Minute),
 				Source:      model.SourceYouTube,
 			},
 		},
 
 		Expected: []*model.Game{
 			&model.Game{
+				ID:   "minecraft",
 				Name: "Minecraft",
 				Content: []*model.Content{
 					&model.Content{
 						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
 						Start:  time.Date(2010, 10, 19, 19, 0, 17, 0, time.UTC),
+						End:    time.Date(2010, 10, 21, 19, 0, 17, 0, time.UTC),
 						Link:   "https://www.youtube.com/watch?v=DM52HxaLK-Y",
+
+						Episodes: 3,
+						Duration: model.Duration(70 * time.Minute),
+						VideoIDs: []string{"DM52HxaLK-Y", "tAaCTvht5Co", "ednqMErMOsM"},
 					},
 				},
+				Confidence: 0.58,
 			},
 		},
 	})
@@ -106,14 +135,20 @@ func TestConvertVideosToGames(t *testing.T) {
 
 		Expected: []*model.Game{
 			&model.Game{
+				ID:   "indiana-jones-and-the-great-circle",
 				Name: "Indiana Jones And The Great Circle",
 				Content: []*model.Content{
 					&model.Content{
 						Source: model.SourceYouTube,
 						Start:  time.Date(2025, 12, 13, 19, 0, 17, 0, time.UTC),
+						End:    time.Date(2025, 12, 15, 19, 0, 17, 0, time.UTC),
 						Link:   "https://www.youtube.com/watch?v=XONCCUxHGxo",
+
+						Episodes: 3,
+						VideoIDs: []string{"XONCCUxHGxo", "wVsDQx0SY1M", "9Ack9uoQRIM"},
 					},
 				},
+				Confidence: 0.68,
 			},
 		},
 	})
@@ -121,6 +156,16 @@ func TestConvertVideosToGames(t *testing.T) {
 	validate(t, &testCase{
 		Name: "Steam",
 
+		Setup: func(provider *mockMetadata.MockProvider) {
+			provider.EXPECT().Game("https://store.steampowered.com/app/2677660").Return(&metadata.Game{
+				Name: "Indiana Jones and the Great Circle",
+				Link: "https://store.steampowered.com/app/2677660",
+				Steam: &model.SteamMetadata{
+					AppID:  "2677660",
+					Genres: []string{"Action", "Adventure"},
+				},
+			}, nil)
+		},
 		Videos: []*model.Video{
 			{
 				Title:       "Der Mann mit dem Hut ist wieder da! 🛕 INDIANA JONES AND THE GREAT CIRCLE #01",
@@ -133,69 +178,831 @@ func TestConvertVideosToGames(t *testing.T) {
 		},
 		Expected: []*model.Game{
 			&model.Game{
-				Name: "Indiana Jones And The Great Circle",
+				ID:   "steam-2677660",
+				Name: "Indiana Jones and the Great Circle",
 				Content: []*model.Content{
 					&model.Content{
 						Source: model.SourceYouTube,
 						Start:  time.Date(2025, 12, 13, 19, 0, 17, 0, time.UTC),
+						End:    time.Date(2025, 12, 13, 19, 0, 17, 0, time.UTC),
 						Link:   "https://www.youtube.com/watch?v=XONCCUxHGxo",
+
+						Episodes: 1,
+						VideoIDs: []string{"XONCCUxHGxo"},
 					},
 				},
+				Steam: &model.SteamMetadata{
+					AppID:  "2677660",
+					Genres: []string{"Action", "Adventure"},
+				},
+				Confidence: 1,
 			},
 		},
 	})
-}
 
-func TestLongestCommonPrefix(t *testing.T) {
-	tests := []struct {
-		name     string
-		str1     string
-		str2     string
-		expected string
-	}{
-		{
-			name:     "Basic example",
-			str1:     "golang",
-			str2:     "golem",
-			expected: "go",
-		},
-		{
-			name:     "No common prefix",
-			str1:     "apple",
-			str2:     "banana",
-			expected: "",
-		},
-		{
-			name:     "One string is prefix of another",
-			str1:     "go",
-			str2:     "golang",
-			expected: "go",
-		},
-		{
-			name:     "Empty string input",
-			str1:     "",
-			str2:     "golang",
-			expected: "",
-		},
-		{
-			name:     "Both empty strings",
-			str1:     "",
-			str2:     "",
-			expected: "",
+	validate(t, &testCase{
+		Name: "Capitalization",
+
+		Videos: []*model.Video{
+			{
+				Title:       "GTA V #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "GTA V #02 Die Bank",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Gta v #03 Verfolgungsjagd",
+				PublishedAt: time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "gta-v",
+				Name: "GTA V",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 3,
+						VideoIDs: []string{"a", "b", "c"},
+					},
+				},
+				Confidence: 0.53,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Same Identifier",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Human Fall Flat #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Human Fall Flat #02 Die Burg",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Human: Fall Flat #03 Das Schloss",
+				PublishedAt: time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Human: Fall Flat #04 Der Turm",
+				PublishedAt: time.Date(2025, 1, 4, 19, 0, 0, 0, time.UTC),
+				VideoID:     "d",
+				Link:        "https://www.youtube.com/watch?v=d",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "human-fall-flat",
+				Name: "Human Fall Flat",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 4, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 4,
+						VideoIDs: []string{"a", "b", "c", "d"},
+					},
+				},
+				Confidence: 0.69,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Co-Op",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Minecraft (LPT mit Sarazar) #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Minecraft #02 Ab in den Nether mit Tobinator",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes:     2,
+						VideoIDs:     []string{"a", "b"},
+						CoOp:         true,
+						Participants: []string{"Sarazar", "Tobinator"},
+					},
+				},
+				Confidence: 0.51,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Kinds",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Angespielt: Subnautica",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Subnautica #01 Abgestürzt",
+				PublishedAt: time.Date(2025, 2, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Subnautica #02 Die Tiefe",
+				PublishedAt: time.Date(2025, 2, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "subnautica",
+				Name: "Subnautica",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindAngespielt,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 1,
+						VideoIDs: []string{"a"},
+					},
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Start:  time.Date(2025, 2, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 2, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=b",
+
+						Episodes: 2,
+						VideoIDs: []string{"b", "c"},
+					},
+				},
+				Confidence: 0.6,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Completed",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Let's Play Subnautica #01 Abgestürzt",
+				PublishedAt: time.Date(2025, 2, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Subnautica #02 Die Tiefe (Ende)",
+				PublishedAt: time.Date(2025, 2, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "subnautica",
+				Name: "Subnautica",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Start:  time.Date(2025, 2, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 2, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes:    2,
+						VideoIDs:    []string{"a", "b"},
+						Completed:   true,
+						CompletedAt: time.Date(2025, 2, 2, 19, 0, 0, 0, time.UTC),
+					},
+				},
+				Confidence: 0.53,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Missing Episodes",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Let's Play Minecraft #02 Die Mine",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #05 Der Nether",
+				PublishedAt: time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Start:  time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=b",
+
+						Episodes:        3,
+						VideoIDs:        []string{"a", "b", "c"},
+						MissingEpisodes: 2,
+					},
+				},
+				Confidence: 0.58,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Re-Run",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Let's Play Minecraft #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2020, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #02 Die Mine",
+				PublishedAt: time.Date(2020, 1, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #03 Der Nether",
+				PublishedAt: time.Date(2022, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #01 Noch einmal",
+				PublishedAt: time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+				VideoID:     "d",
+				Link:        "https://www.youtube.com/watch?v=d",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #02 Wieder die Mine",
+				PublishedAt: time.Date(2024, 1, 2, 19, 0, 0, 0, time.UTC),
+				VideoID:     "e",
+				Link:        "https://www.youtube.com/watch?v=e",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Start:  time.Date(2020, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2022, 1, 1, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 3,
+						VideoIDs: []string{"a", "b", "c"},
+					},
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Run:    1,
+						Start:  time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2024, 1, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=d",
+
+						Episodes: 2,
+						VideoIDs: []string{"d", "e"},
+					},
+				},
+				Confidence: 0.64,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Re-Upload",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Let's Play Minecraft #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				ChannelID:   "main",
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #02 Die Mine",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				ChannelID:   "main",
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #01 Ein neuer Anfang (Re-Upload)",
+				PublishedAt: time.Date(2025, 3, 1, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour + time.Second),
+				ChannelID:   "mirror",
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 2,
+						Duration: model.Duration(2 * time.Hour),
+						VideoIDs: []string{"a", "b"},
+					},
+				},
+				Confidence: 0.51,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Shorts Excluded",
+
+		Shorts: ShortsExclude,
+		Videos: []*model.Video{
+			{
+				Title:       "Let's Play Minecraft #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #02 Die Mine",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "MINECRAFT: Der Creeper kam aus dem Nichts #shorts",
+				PublishedAt: time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(40 * time.Second),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Bester Moment des Jahres",
+				PublishedAt: time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(2 * time.Minute),
+				Short:       true,
+				VideoID:     "d",
+				Link:        "https://www.youtube.com/watch?v=d",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 2,
+						Duration: model.Duration(2 * time.Hour),
+						VideoIDs: []string{"a", "b"},
+					},
+				},
+				Confidence: 0.51,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Shorts Known",
+
+		Shorts: ShortsKnown,
+		Videos: []*model.Video{
+			{
+				Title:       "Let's Play Minecraft #01 Ein neuer Anfang",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Let's Play Minecraft #02 Die Mine",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "MINECRAFT: Der Creeper kam aus dem Nichts #shorts",
+				PublishedAt: time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(40 * time.Second),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Bester Moment des Jahres",
+				PublishedAt: time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(2 * time.Minute),
+				Short:       true,
+				VideoID:     "d",
+				Link:        "https://www.youtube.com/watch?v=d",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindClip,
+						Start:  time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=c",
+
+						Episodes: 1,
+						Duration: model.Duration(40 * time.Second),
+						VideoIDs: []string{"c"},
+					},
+					&model.Content{
+						Source: model.SourceYouTube,
+						Kind:   model.KindLetsPlay,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 2,
+						Duration: model.Duration(2 * time.Hour),
+						VideoIDs: []string{"a", "b"},
+					},
+				},
+				Confidence: 0.58,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Long Break",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Minecraft - Alles auf Anfang",
+				PublishedAt: time.Date(2014, 1, 1, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Minecraft - Die Mine",
+				PublishedAt: time.Date(2014, 1, 2, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Minecraft - Zurück in der Mine",
+				PublishedAt: time.Date(2023, 1, 1, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Start:  time.Date(2014, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2014, 1, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 2,
+						Duration: model.Duration(2 * time.Hour),
+						VideoIDs: []string{"a", "b"},
+					},
+					&model.Content{
+						Source: model.SourceYouTube,
+						Start:  time.Date(2023, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2023, 1, 1, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=c",
+						Run:    1,
+
+						Episodes: 1,
+						Duration: model.Duration(time.Hour),
+						VideoIDs: []string{"c"},
+					},
+				},
+				Confidence: 0.58,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Playlists",
+
+		Videos: []*model.Video{
+			{
+				Title:       "Minecraft - Alles auf Anfang",
+				PublishedAt: time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				Playlist:    "PLa",
+				VideoID:     "a",
+				Link:        "https://www.youtube.com/watch?v=a",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Minecraft - Die Mine",
+				PublishedAt: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				Playlist:    "PLa",
+				VideoID:     "b",
+				Link:        "https://www.youtube.com/watch?v=b",
+				Source:      model.SourceYouTube,
+			},
+			{
+				Title:       "Minecraft - Hardcore",
+				PublishedAt: time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+				Duration:    model.Duration(time.Hour),
+				Playlist:    "PLb",
+				VideoID:     "c",
+				Link:        "https://www.youtube.com/watch?v=c",
+				Source:      model.SourceYouTube,
+			},
+		},
+		Expected: []*model.Game{
+			&model.Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*model.Content{
+					&model.Content{
+						Source: model.SourceYouTube,
+						Start:  time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=a",
+
+						Episodes: 2,
+						Duration: model.Duration(2 * time.Hour),
+						VideoIDs: []string{"a", "b"},
+					},
+					&model.Content{
+						Source: model.SourceYouTube,
+						Start:  time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+						End:    time.Date(2025, 1, 3, 19, 0, 0, 0, time.UTC),
+						Link:   "https://www.youtube.com/watch?v=c",
+						Run:    1,
+
+						Episodes: 1,
+						Duration: model.Duration(time.Hour),
+						VideoIDs: []string{"c"},
+					},
+				},
+				Confidence: 0.78,
+			},
 		},
+	})
+}
+
+// fixtures holds the fixture video files, with "videos_formats.csv" covering the title formats of the different series of a channel.
+var fixtures = []string{"videos.csv", "videos_formats.csv"}
+
+func readFixtureVideos(t *testing.T, fixture string) []*model.Video {
+	file, err := os.Open(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, file.Close()) }()
+	videos, err := model.VideoCSVRead(file)
+	require.NoError(t, err)
+	require.Greater(t, len(videos), 200)
+
+	return videos
+}
+
+func convertToJSON(t *testing.T, videos []*model.Video) string {
+	return convertToJSONWith(t, videos, metadata.Chain{}, 1)
+}
+
+func convertToJSONWith(t *testing.T, videos []*model.Video, provider metadata.Provider, workers uint) string {
+	converter := NewVideoToGameConverter(provider, nil, ShortsInclude, workers, zaptest.NewLogger(t, zaptest.Level(zap.InfoLevel)))
+	games, err := converter.Convert(videos)
+	require.NoError(t, err)
+	ids := map[string]bool{}
+	for _, game := range games {
+		assert.False(t, ids[game.ID], "duplicate game ID %q", game.ID)
+		ids[game.ID] = true
 	}
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			actual := longestCommonPrefix(test.str1, test.str2)
-			assert.Equal(t, test.expected, actual)
+	var output bytes.Buffer
+	require.NoError(t, model.JSONWrite(&output, &model.Data{Games: games}))
+
+	return output.String()
+}
+
+func TestConvertDeterministic(t *testing.T) {
+	for _, fixture := range fixtures {
+		t.Run(fixture, func(t *testing.T) {
+			videos := readFixtureVideos(t, fixture)
+
+			assert.Equal(t, convertToJSON(t, videos), convertToJSON(t, videos))
 		})
 	}
 }
 
-func TestReverseString(t *testing.T) {
-	assert.Equal(t, "aaa", reverseString("aaa"))
-	assert.Equal(t, "", reverseString(""))
-	assert.Equal(t, "abc", reverseString("cba"))
-	assert.Equal(t, "abcd", reverseString("dcba"))
+func TestConvertInputOrder(t *testing.T) {
+	for _, fixture := range fixtures {
+		t.Run(fixture, func(t *testing.T) {
+			videos := readFixtureVideos(t, fixture)
+			expected := convertToJSON(t, videos)
+
+			t.Run("Reversed", func(t *testing.T) {
+				reversed := slices.Clone(videos)
+				slices.Reverse(reversed)
+
+				assert.Equal(t, expected, convertToJSON(t, reversed))
+			})
+
+			t.Run("Shuffled", func(t *testing.T) {
+				random := rand.New(rand.NewPCG(32, 32))
+				for range 3 {
+					shuffled := slices.Clone(videos)
+					random.Shuffle(len(shuffled), func(i, j int) {
+						shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+					})
+
+					assert.Equal(t, expected, convertToJSON(t, shuffled))
+				}
+			})
+
+			t.Run("Channels", func(t *testing.T) {
+				// Videos of another channel published in between are ordered separately.
+				sideChannel := []*model.Video{
+					{
+						Title:       "Minecraft Shorts Highlights",
+						PublishedAt: videos[0].PublishedAt,
+						VideoID:     "side1",
+						ChannelID:   "side",
+						Source:      model.SourceYouTube,
+					},
+					{
+						Title:       "Minecraft Shorts Best-of",
+						PublishedAt: videos[len(videos)-1].PublishedAt,
+						VideoID:     "side2",
+						ChannelID:   "side",
+						Source:      model.SourceYouTube,
+					},
+				}
+				withSideChannel := append(slices.Clone(sideChannel), videos...)
+				reversed := slices.Clone(withSideChannel)
+				slices.Reverse(reversed)
+
+				assert.Equal(t, convertToJSON(t, withSideChannel), convertToJSON(t, reversed))
+			})
+		})
+	}
+}
+
+func TestConvertWorkers(t *testing.T) {
+	videos := readFixtureVideos(t, "videos.csv")
+	// linkedVideos holds the name of the game linked in the description of a video.
+	linkedVideos := map[string]string{}
+	slugSeparatorRE := regexp.MustCompile(`[^a-z0-9]+`)
+	for i, video := range videos {
+		if i%7 == 0 { // Resolve some games via store links of their series, e.g. "Heute geht es weiter mit Minecraft!".
+			series := strings.TrimSuffix(strings.TrimPrefix(video.Description, "Heute geht es weiter mit "), "!")
+			require.NotEqual(t, video.Description, series, "unexpected fixture description %q", video.Description)
+			slug := strings.Trim(slugSeparatorRE.ReplaceAllString(strings.ToLower(series), "_"), "_")
+			video.Description += " https://www.gog.com/game/" + slug
+			linkedVideos[video.VideoID] = strings.ReplaceAll(slug, "_", " ")
+		}
+	}
+	provider := metadata.Chain{
+		metadata.NewGOGProvider(),
+	}
+
+	expected := convertToJSONWith(t, videos, provider, 1)
+	data, err := model.JSONRead(strings.NewReader(expected))
+	require.NoError(t, err)
+	for _, game := range data.Games {
+		for _, content := range game.Content {
+			for _, videoID := range content.VideoIDs {
+				if name, ok := linkedVideos[videoID]; ok {
+					assert.Equalf(t, name, strings.ToLower(game.Name), "video %s", videoID)
+					delete(linkedVideos, videoID)
+				}
+			}
+		}
+	}
+	assert.Empty(t, linkedVideos, "videos with store links are not converted")
+
+	for _, workers := range []uint{2, 8, 64} {
+		assert.Equalf(t, expected, convertToJSONWith(t, videos, provider, workers), "workers=%d", workers)
+	}
 }
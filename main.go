@@ -46,6 +46,15 @@ func main() {
 		panic(err)
 	}
 
+	if _, err := parser.AddCommand(
+		"schema",
+		"Generate the data schema.",
+		"Generate the JSON Schema of the game JSON.",
+		cmd.NewSchemaCommand(logger),
+	); err != nil {
+		panic(err)
+	}
+
 	if _, err := parser.Parse(); err != nil {
 		if flagsErr, ok := err.(*flags.Error); ok && flagsErr.Type == flags.ErrHelp {
 			os.Exit(0)
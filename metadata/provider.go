@@ -0,0 +1,50 @@
+package metadata
+
+import (
+	goerrors "errors"
+
+	"github.com/bauersimon/grnkdb/model"
+)
+
+// Game holds game metadata resolved from a video.
+type Game struct {
+	// Name is the name of the game.
+	Name string
+	// NameFromSlug denotes that the name is only derived from the slug of the store link, e.g. in lowercase, and should only be used if no better name is known.
+	NameFromSlug bool
+	// Link is the full URL of the store link the game was resolved from.
+	Link string
+	// Steam holds the Steam store metadata, if known.
+	Steam *model.SteamMetadata
+}
+
+// Provider defines a generic game metadata provider.
+type Provider interface {
+	// Game resolves the game referenced in a video description.
+	// Returns "nil" if the description does not reference a game known to the provider.
+	Game(description string) (*Game, error)
+}
+
+// Chain queries providers in priority order.
+type Chain []Provider
+
+var _ Provider = (Chain)(nil)
+
+// Game returns the game of the first provider that resolves the description.
+// Errors of providers are only returned if no provider resolved the description.
+func (c Chain) Game(description string) (*Game, error) {
+	var errs []error
+	for _, provider := range c {
+		game, err := provider.Game(description)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+		if game != nil {
+			return game, nil
+		}
+	}
+
+	return nil, goerrors.Join(errs...)
+}
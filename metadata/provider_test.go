@@ -0,0 +1,170 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticProvider struct {
+	game *Game
+	err  error
+}
+
+func (p *staticProvider) Game(description string) (*Game, error) {
+	return p.game, p.err
+}
+
+func TestChainGame(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Chain Chain
+
+		Expected *Game
+		Error    string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := tc.Chain.Game("description")
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Empty",
+	})
+
+	validate(t, &testCase{
+		Name: "Priority",
+
+		Chain: Chain{
+			&staticProvider{},
+			&staticProvider{game: &Game{Name: "foo"}},
+			&staticProvider{game: &Game{Name: "bar"}},
+		},
+
+		Expected: &Game{Name: "foo"},
+	})
+
+	validate(t, &testCase{
+		Name: "Error Skipped",
+
+		Chain: Chain{
+			&staticProvider{err: assert.AnError},
+			&staticProvider{game: &Game{Name: "foo"}},
+		},
+
+		Expected: &Game{Name: "foo"},
+	})
+
+	validate(t, &testCase{
+		Name: "Error",
+
+		Chain: Chain{
+			&staticProvider{err: assert.AnError},
+			&staticProvider{},
+		},
+
+		Error: assert.AnError.Error(),
+	})
+}
+
+func TestStoreLinkProviders(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Provider    Provider
+		Description string
+
+		Expected *Game
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := tc.Provider.Game(tc.Description)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "No Link",
+
+		Provider:    NewGOGProvider(),
+		Description: "Heute geht es weiter! https://www.youtube.com/gronkh",
+	})
+
+	validate(t, &testCase{
+		Name: "GOG",
+
+		Provider:    NewGOGProvider(),
+		Description: "Hol dir das Spiel: https://www.gog.com/de/game/the_witcher_3_wild_hunt\nViel Spaß!",
+
+		Expected: &Game{
+			Name:         "the witcher 3 wild hunt",
+			NameFromSlug: true,
+			Link:         "https://www.gog.com/de/game/the_witcher_3_wild_hunt",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Epic",
+
+		Provider:    NewEpicProvider(),
+		Description: "https://store.epicgames.com/de/p/hogwarts-legacy",
+
+		Expected: &Game{
+			Name:         "hogwarts legacy",
+			NameFromSlug: true,
+			Link:         "https://store.epicgames.com/de/p/hogwarts-legacy",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "itch.io",
+
+		Provider:    NewItchProvider(),
+		Description: "Spiel hier: https://somedev.itch.io/tiny-dungeon-crawler",
+
+		Expected: &Game{
+			Name:         "tiny dungeon crawler",
+			NameFromSlug: true,
+			Link:         "https://somedev.itch.io/tiny-dungeon-crawler",
+		},
+	})
+
+	t.Run("eShop", func(t *testing.T) {
+		validate(t, &testCase{
+			Name: "Europe",
+
+			Provider:    NewEShopProvider(),
+			Description: "https://www.nintendo.com/de-de/Spiele/Nintendo-Switch-Spiele/Super-Mario-Odyssey-1173332.html",
+
+			Expected: &Game{
+				Name:         "Super Mario Odyssey",
+				NameFromSlug: true,
+				Link:         "https://www.nintendo.com/de-de/Spiele/Nintendo-Switch-Spiele/Super-Mario-Odyssey-1173332.html",
+			},
+		})
+		validate(t, &testCase{
+			Name: "America",
+
+			Provider:    NewEShopProvider(),
+			Description: "https://www.nintendo.com/us/store/products/super-mario-odyssey-switch/",
+
+			Expected: &Game{
+				Name:         "super mario odyssey",
+				NameFromSlug: true,
+				Link:         "https://www.nintendo.com/us/store/products/super-mario-odyssey-switch/",
+			},
+		})
+	})
+}
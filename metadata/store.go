@@ -0,0 +1,72 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+)
+
+// storeLinkProvider resolves games from store links by the slug of the link.
+type storeLinkProvider struct {
+	// linkRE matches a store link without its scheme, the first group holds the slug.
+	linkRE *regexp.Regexp
+	// trimRE matches slug parts that are not part of the name, e.g. IDs or platform suffixes.
+	// Nothing is trimmed if "nil".
+	trimRE *regexp.Regexp
+}
+
+var _ Provider = (*storeLinkProvider)(nil)
+
+var slugSeparatorRE = regexp.MustCompile(`[-_+\s]+`)
+
+// Game resolves the game of the first store link in the description.
+// The name of the game is derived from the slug of the link, which is why it is marked as such.
+func (p *storeLinkProvider) Game(description string) (*Game, error) {
+	match := p.linkRE.FindStringSubmatch(description)
+	if len(match) == 0 {
+		return nil, nil
+	}
+
+	slug := match[1]
+	if p.trimRE != nil {
+		slug = p.trimRE.ReplaceAllString(slug, "")
+	}
+	name := strings.TrimSpace(slugSeparatorRE.ReplaceAllString(slug, " "))
+	if name == "" {
+		return nil, nil
+	}
+
+	return &Game{
+		Name:         name,
+		NameFromSlug: true,
+		Link:         "https://" + strings.TrimSpace(match[0]),
+	}, nil
+}
+
+// NewGOGProvider returns a provider resolving GOG store links.
+func NewGOGProvider() Provider {
+	return &storeLinkProvider{
+		linkRE: regexp.MustCompile(`(?i)(?:www\.)?gog\.com/(?:[a-z]{2}/)?game/([\w-]+)`),
+	}
+}
+
+// NewEpicProvider returns a provider resolving Epic Games Store links.
+func NewEpicProvider() Provider {
+	return &storeLinkProvider{
+		linkRE: regexp.MustCompile(`(?i)(?:store\.epicgames\.com/(?:[a-z]{2}(?:-[a-z]{2})?/)?p|(?:www\.)?epicgames\.com/store/(?:[a-z]{2}(?:-[a-z]{2})?/)?(?:p|product))/([\w-]+)`),
+	}
+}
+
+// NewItchProvider returns a provider resolving itch.io links.
+func NewItchProvider() Provider {
+	return &storeLinkProvider{
+		linkRE: regexp.MustCompile(`(?i)[\w-]+\.itch\.io/([\w-]+)`),
+	}
+}
+
+// NewEShopProvider returns a provider resolving Nintendo eShop links.
+func NewEShopProvider() Provider {
+	return &storeLinkProvider{
+		linkRE: regexp.MustCompile(`(?i)(?:www\.)?nintendo\.com/(?:[a-z]{2}(?:-[a-z]{2})?/)?(?:store/products|Spiele/[\w-]+|Games/[\w-]+)/([\w-]+?)(?:\.html|/|\s|$)`),
+		trimRE: regexp.MustCompile(`(?i)-(?:\d+|switch|switch-2)$`),
+	}
+}
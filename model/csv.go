@@ -1,7 +1,9 @@
 package model
 
 import (
+	"encoding/csv"
 	"io"
+	"iter"
 	"slices"
 	"strings"
 
@@ -16,26 +18,32 @@ func VideoCSVWrite(writer io.Writer, videos []*Video) error {
 		return strings.Compare(a.VideoID, b.VideoID)
 	})
 
-	data, err := csvutil.Marshal(videos)
-	if err != nil {
+	return VideoCSVWriteSeq(writer, slices.Values(videos))
+}
+
+// VideoCSVWriteSeq writes video information as CSV format one video at a time, in the order of the iterator.
+func VideoCSVWriteSeq(writer io.Writer, videos iter.Seq[*Video]) error {
+	csvWriter := csv.NewWriter(writer)
+	encoder := csvutil.NewEncoder(csvWriter)
+	if err := encoder.EncodeHeader(Video{}); err != nil {
 		return errors.WithStack(err)
 	}
+	for video := range videos {
+		if err := encoder.Encode(video); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	csvWriter.Flush()
 
-	_, err = writer.Write(data)
-	return errors.WithStack(err)
+	return errors.WithStack(csvWriter.Error())
 }
 
 // VideoCSVRead reads video information from CSV format.
 func VideoCSVRead(reader io.Reader) ([]*Video, error) {
-	data, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-
-	var videos []*Video
-	err = csvutil.Unmarshal(data, &videos)
-	if err != nil {
-		return nil, errors.WithStack(err)
+	videoReader := NewVideoCSVReader(reader)
+	videos := slices.AppendSeq([]*Video{}, videoReader.Videos())
+	if err := videoReader.Err(); err != nil {
+		return nil, err
 	}
 
 	// Sort videos by VideoID for consistent output
@@ -45,3 +53,54 @@ func VideoCSVRead(reader io.Reader) ([]*Video, error) {
 
 	return videos, nil
 }
+
+// VideoCSVReader reads video information from CSV format one video at a time.
+type VideoCSVReader struct {
+	reader io.Reader
+
+	err error
+}
+
+// NewVideoCSVReader returns a new reader of video information in CSV format.
+func NewVideoCSVReader(reader io.Reader) *VideoCSVReader {
+	return &VideoCSVReader{
+		reader: reader,
+	}
+}
+
+// Videos returns an iterator over the videos in the order of the input, which can be iterated once.
+// The iteration stops at the first error, which is reported by "Err".
+func (r *VideoCSVReader) Videos() iter.Seq[*Video] {
+	return func(yield func(*Video) bool) {
+		csvReader := csv.NewReader(r.reader)
+		csvReader.ReuseRecord = true
+		decoder, err := csvutil.NewDecoder(csvReader)
+		if err == io.EOF { // No header means no videos.
+			return
+		} else if err != nil {
+			r.err = errors.WithStack(err)
+
+			return
+		}
+
+		for {
+			var video Video
+			if err := decoder.Decode(&video); err == io.EOF {
+				return
+			} else if err != nil {
+				r.err = errors.WithStack(err)
+
+				return
+			}
+
+			if !yield(&video) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (r *VideoCSVReader) Err() error {
+	return r.err
+}
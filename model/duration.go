@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Duration is a duration that is serialized in a human-readable form, e.g. "1h2m3s".
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	if d == 0 {
+		return []byte{}, nil
+	}
+
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = 0
+
+		return nil
+	}
+
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	*d = Duration(duration)
+
+	return nil
+}
@@ -1,17 +1,107 @@
 package model
 
 import (
+	"cmp"
+	"iter"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Game represents a game.
 type Game struct {
+	// ID is the stable identifier of the game, which is kept when the game is renamed.
+	ID string `json:",omitempty"`
 	// Name is the title of the game.
 	Name string
+	// PreviousNames holds the names the game was known by before it was renamed, sorted by name.
+	PreviousNames []string `json:",omitempty"`
 	// Content is the content produced with this game.
 	Content []*Content
+	// Steam holds the Steam store metadata of the game, if known.
+	Steam *SteamMetadata `json:",omitempty"`
+	// Confidence is the confidence in the identification of the game from its videos between 0 and 1, zero if unknown.
+	Confidence float64 `json:",omitempty"`
+}
+
+// NewGameID returns the identifier of a new game, i.e. "steam-<AppID>" if the game is known on Steam and a slug of its name otherwise.
+// Names with the same slug, e.g. differing only in punctuation, denote the same game.
+func NewGameID(name string, steam *SteamMetadata) string {
+	if steam != nil && steam.AppID != "" {
+		return "steam-" + steam.AppID
+	}
+
+	return slug(name)
+}
+
+var (
+	slugReplacer    = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss")
+	slugSeparatorRE = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// slug returns the lowercase form of a name with diacritics removed and words joined by dashes, e.g. "pokemon-karmesin" for "Pokémon Karmesin".
+func slug(name string) string {
+	s := slugReplacer.Replace(strings.ToLower(name))
+	// The transformer holds state, so it is created for every call to be safe for concurrent use.
+	if folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s); err == nil {
+		s = folded
+	}
+
+	return strings.Trim(slugSeparatorRE.ReplaceAllString(s, "-"), "-")
+}
+
+// key returns the identifier of the game, or a new one if the game has none yet.
+func (g *Game) key() string {
+	if g.ID != "" {
+		return g.ID
+	}
+
+	return NewGameID(g.Name, g.Steam)
+}
+
+// matchKeys returns the keys by which the game is matched with other games, from the most to the least specific one.
+func (g *Game) matchKeys() []string {
+	keys := []string{"id:" + g.key(), "name:" + strings.ToLower(g.Name)}
+	for _, content := range g.Content {
+		if content.Link != "" {
+			keys = append(keys, "link:"+content.Link)
+		}
+	}
+
+	return keys
+}
+
+// SteamMetadata holds the Steam store metadata of a game.
+type SteamMetadata struct {
+	// AppID is the Steam AppID.
+	AppID string
+	// Type is the app type, e.g. "game" or "dlc".
+	Type string `json:",omitempty"`
+	// Genres holds the genres of the game.
+	Genres []string `json:",omitempty"`
+	// Categories holds the store categories of the game, e.g. "Single-player".
+	Categories []string `json:",omitempty"`
+	// ReleaseDate is the release date of the game.
+	ReleaseDate time.Time `json:",omitzero"`
+	// Developers holds the developers of the game.
+	Developers []string `json:",omitempty"`
+	// Publishers holds the publishers of the game.
+	Publishers []string `json:",omitempty"`
+	// HeaderImage is the URL of the store header image.
+	HeaderImage string `json:",omitempty"`
+	// Platforms holds the supported platforms, e.g. "windows".
+	Platforms []string `json:",omitempty"`
+}
+
+// StoreLink returns the URL of the Steam store page.
+func (s *SteamMetadata) StoreLink() string {
+	return "https://store.steampowered.com/app/" + s.AppID
 }
 
 // SourceType is a source type.
@@ -33,46 +123,430 @@ var (
 	SourceYouTube = sourceType("youtube")
 )
 
+// ContentKind is the kind of content, e.g. a full playthrough or a one-off test.
+type ContentKind string
+
+var allContentKinds []ContentKind
+
+func contentKind(s string) ContentKind {
+	newContentKind := ContentKind(s)
+	allContentKinds = append(allContentKinds, newContentKind)
+	slices.SortStableFunc(allContentKinds, func(a, b ContentKind) int {
+		return strings.Compare(string(a), string(b))
+	})
+	return newContentKind
+}
+
+var (
+	// KindLetsPlay is a full playthrough.
+	KindLetsPlay = contentKind("letsplay")
+	// KindLetsTest is a one-off test.
+	KindLetsTest = contentKind("letstest")
+	// KindAngespielt is a short first look.
+	KindAngespielt = contentKind("angespielt")
+	// KindDemo is a playthrough of a demo.
+	KindDemo = contentKind("demo")
+	// KindStream is a livestream recording.
+	KindStream = contentKind("stream")
+	// KindClip is a YouTube Short or a highlight clip.
+	KindClip = contentKind("clip")
+)
+
+// Valid checks if the content kind is known.
+func (k ContentKind) Valid() bool {
+	return slices.Contains(allContentKinds, k)
+}
+
 // Content represents content.
 type Content struct {
 	// Link is a URL to the content.
 	Link string
 	// Start denotes when the content was first released.
 	Start time.Time
+	// End denotes when the content was last released.
+	End time.Time `json:",omitzero"`
+	// Episodes is the number of episodes.
+	Episodes int `json:",omitempty"`
+	// Duration is the total duration of all episodes, zero if unknown.
+	Duration Duration `json:",omitzero"`
+	// VideoIDs holds the video IDs of all episodes.
+	VideoIDs []string `json:",omitempty"`
+	// MissingEpisodes is the number of episodes missing from the episode numbering.
+	MissingEpisodes int `json:",omitempty"`
+	// CoOp denotes if the content was played together with others.
+	CoOp bool `json:",omitempty"`
+	// Participants holds the names of the co-players and guests, sorted by name.
+	Participants []string `json:",omitempty"`
+	// Completed denotes if the final episode was released.
+	Completed bool `json:",omitempty"`
+	// CompletedAt denotes when the final episode was released.
+	CompletedAt time.Time `json:",omitzero"`
 	// Source is the source of the content.
 	Source SourceType
+	// Kind is the kind of content, empty if unknown.
+	Kind ContentKind `json:",omitempty"`
+	// Run is the number of earlier runs of the same source and kind, e.g. 1 for the first re-run.
+	Run int `json:",omitempty"`
 }
 
-// MergeGames merges two slices of games.
-func MergeGames(a []*Game, b []*Game) []*Game {
-	merged := append(a, b...)
-	slices.SortStableFunc(merged, func(a *Game, b *Game) int {
-		return strings.Compare(a.Name, b.Name)
+// RunGap is the break after which content starts a new run of a series, unless it continues the episode numbering.
+const RunGap = 365 * 24 * time.Hour
+
+// last returns when the last episode of the content was released.
+func (c *Content) last() time.Time {
+	if c.End.IsZero() {
+		return c.Start
+	}
+
+	return c.End
+}
+
+// gap returns the break between the content and other content, zero if they overlap.
+func (c *Content) gap(other *Content) time.Duration {
+	return max(0, other.Start.Sub(c.last()), c.Start.Sub(other.last()))
+}
+
+// sharesVideos checks if the content and other content share an episode.
+func (c *Content) sharesVideos(other *Content) bool {
+	return slices.ContainsFunc(c.VideoIDs, func(videoID string) bool {
+		return slices.Contains(other.VideoIDs, videoID)
 	})
+}
+
+// isLegacy checks if the content was written before kinds and episodes were recorded, so it covers all runs of its source.
+func (c *Content) isLegacy() bool {
+	return c.Kind == "" && len(c.VideoIDs) == 0
+}
+
+// clone returns a copy of the game that shares no content with the game.
+func (g *Game) clone() *Game {
+	clone := *g
+	clone.PreviousNames = slices.Clone(g.PreviousNames)
+	clone.Content = nil
+	for _, content := range g.Content {
+		clone.Content = append(clone.Content, content.clone())
+	}
+
+	return &clone
+}
+
+// clone returns a copy of the content.
+func (c *Content) clone() *Content {
+	clone := *c
+	clone.VideoIDs = slices.Clone(c.VideoIDs)
+	clone.Participants = slices.Clone(c.Participants)
+
+	return &clone
+}
+
+// merge merges the episodes of other content of the same run into the content.
+// The start of the content is not changed, as it depends on the merge policy.
+func (c *Content) merge(other *Content) {
+	if other.End.After(c.End) {
+		c.End = other.End
+	}
+
+	known := map[string]bool{}
+	for _, videoID := range c.VideoIDs {
+		known[videoID] = true
+	}
+	disjoint := true
+	for _, videoID := range other.VideoIDs {
+		if known[videoID] {
+			disjoint = false
+
+			continue
+		}
+		known[videoID] = true
+		c.VideoIDs = append(c.VideoIDs, videoID)
+	}
+
+	// Durations can only be summed up if no episodes are shared, otherwise the longer one is the best estimate.
+	if disjoint {
+		c.Duration += other.Duration
+	} else {
+		c.Duration = max(c.Duration, other.Duration)
+	}
+
+	if len(c.VideoIDs) > 0 {
+		c.Episodes = len(c.VideoIDs)
+	} else {
+		c.Episodes = max(c.Episodes, other.Episodes)
+	}
+
+	c.CoOp = c.CoOp || other.CoOp
+	c.Completed = c.Completed || other.Completed
+	if other.CompletedAt.After(c.CompletedAt) {
+		c.CompletedAt = other.CompletedAt
+	}
+	if len(other.Participants) > 0 {
+		c.Participants = slices.Compact(slices.Sorted(slices.Values(append(slices.Clone(c.Participants), other.Participants...))))
+	}
+}
+
+// compareContent orders content by source, kind and run.
+func compareContent(a, b *Content) int {
+	if c := strings.Compare(string(a.Source), string(b.Source)); c != 0 {
+		return c
+	} else if c := strings.Compare(string(a.Kind), string(b.Kind)); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.Run, b.Run)
+}
 
-	return slices.CompactFunc(merged, func(a *Game, b *Game) bool {
-		if a.Name != b.Name {
-			return false
+// MergePolicy defines how conflicting values of a new and an existing game are resolved while merging.
+type MergePolicy string
+
+const (
+	// MergePreferExisting keeps the values of the existing game.
+	MergePreferExisting MergePolicy = "prefer-existing"
+	// MergePreferNew keeps the values of the new game.
+	MergePreferNew MergePolicy = "prefer-new"
+	// MergeEarliest keeps the earliest start of content and the values of the new game otherwise.
+	MergeEarliest MergePolicy = "earliest"
+)
+
+// MergeConflict is a conflict between the values of a new and an existing game that was resolved while merging.
+type MergeConflict struct {
+	// Game is the name of the merged game.
+	Game string
+	// Field is the conflicting field, e.g. "Name" or "Content.Start".
+	Field string
+	// Content is the link of the merged content, empty for conflicts of the game itself.
+	Content string `json:",omitempty"`
+	// New is the value of the new game.
+	New string
+	// Existing is the value of the existing game.
+	Existing string
+	// Resolved is the value that was kept.
+	Resolved string
+}
+
+// merger merges games with a policy and records the conflicts it resolved.
+type merger struct {
+	policy    MergePolicy
+	conflicts []*MergeConflict
+}
+
+// resolve records a conflict if the values of a new and an existing game differ and returns if the new value is kept.
+// The new value is kept if "preferNew" is set.
+func (m *merger) resolve(game *Game, content *Content, field string, newValue string, existingValue string, preferNew bool) bool {
+	if newValue == existingValue {
+		return true
+	}
+
+	conflict := &MergeConflict{
+		Game:     game.Name,
+		Field:    field,
+		New:      newValue,
+		Existing: existingValue,
+		Resolved: newValue,
+	}
+	if !preferNew {
+		conflict.Resolved = existingValue
+	}
+	if content != nil {
+		conflict.Content = content.Link
+	}
+	m.conflicts = append(m.conflicts, conflict)
+
+	return preferNew
+}
+
+// mergeGame merges an existing game into a new game.
+// The identifier of the existing game is kept and replaced names are kept as previous names.
+func (m *merger) mergeGame(game *Game, existing *Game) {
+	preferNew := m.policy != MergePreferExisting
+
+	if existing.ID != "" {
+		game.ID = existing.ID
+	}
+	names := slices.Concat(game.PreviousNames, existing.PreviousNames)
+	if m.resolve(game, nil, "Name", game.Name, existing.Name, preferNew) {
+		names = append(names, existing.Name)
+	} else { // The rejected new name was never published, so it is no previous name.
+		game.Name = existing.Name
+	}
+	game.PreviousNames = nil
+	for _, name := range slices.Compact(slices.Sorted(slices.Values(names))) {
+		if !strings.EqualFold(name, game.Name) { // Corrected capitalization is no rename.
+			game.PreviousNames = append(game.PreviousNames, name)
 		}
+	}
+	if game.Steam == nil {
+		game.Steam = existing.Steam
+	} else if existing.Steam != nil && !m.resolve(game, nil, "Steam", game.Steam.AppID, existing.Steam.AppID, preferNew) {
+		game.Steam = existing.Steam
+	}
+	if game.Confidence == 0 { // The confidence is derived from the videos, so a new one is always kept.
+		game.Confidence = existing.Confidence
+	}
+
+	// Content of different kinds or runs is kept separately, e.g. a test and a later playthrough.
+	game.Content = m.mergeContent(game, game.Content, existing.Content)
+}
 
-		a.Content = append(a.Content, b.Content...)
-		slices.SortStableFunc(a.Content, func(a *Content, b *Content) int {
-			return strings.Compare(string(a.Source), string(b.Source))
+// mergeContent merges existing content into new content of the same run, matched by link, shared episodes or closeness in time, and renumbers the runs.
+func (m *merger) mergeContent(game *Game, content []*Content, existingContent []*Content) (merged []*Content) {
+	preferNew := m.policy != MergePreferExisting
+
+	merged = slices.Clone(content)
+	for _, c := range existingContent {
+		i := slices.IndexFunc(content, func(m *Content) bool {
+			return c.Link != "" && m.Link == c.Link
 		})
+		if i < 0 {
+			i = slices.IndexFunc(content, c.sharesVideos)
+		}
+		if i < 0 {
+			i = slices.IndexFunc(content, func(m *Content) bool {
+				return m.Source == c.Source && m.Run == c.Run && (m.isLegacy() || c.isLegacy())
+			})
+		}
+		if i < 0 {
+			for j, candidate := range content {
+				if candidate.Source != c.Source || (candidate.Kind != c.Kind && candidate.Kind != "" && c.Kind != "") || candidate.gap(c) >= RunGap {
+					continue
+				} else if i < 0 || candidate.gap(c) < content[i].gap(c) {
+					i = j
+				}
+			}
+		}
+		if i < 0 {
+			merged = append(merged, c)
+
+			continue
+		}
+
+		kept := content[i] // The new content is kept.
+		if kept.Kind == "" {
+			kept.Kind = c.Kind
+		} else if c.Kind != "" && !m.resolve(game, kept, "Content.Kind", string(kept.Kind), string(c.Kind), preferNew) {
+			kept.Kind = c.Kind
+		}
+		preferNewStart := preferNew
+		if m.policy == MergeEarliest {
+			preferNewStart = !c.Start.Before(kept.Start)
+		}
+		if !m.resolve(game, kept, "Content.Start", kept.Start.Format(time.RFC3339), c.Start.Format(time.RFC3339), preferNewStart) {
+			kept.Start = c.Start
+			kept.Link = c.Link
+		}
+		kept.merge(c)
+	}
+
+	// Number the runs of each source and kind by their start.
+	slices.SortStableFunc(merged, func(a, b *Content) int {
+		return a.Start.Compare(b.Start)
+	})
+	runs := map[string]int{}
+	for _, c := range merged {
+		key := string(c.Source) + "/" + string(c.Kind)
+		c.Run = runs[key]
+		runs[key]++
+	}
+	slices.SortStableFunc(merged, compareContent)
+
+	return merged
+}
 
-		a.Content = slices.CompactFunc(a.Content, func(a *Content, b *Content) bool {
-			if a.Source != b.Source {
-				return false
+// Tombstone marks a deleted game which must not be added again.
+type Tombstone struct {
+	// ID is the identifier of the deleted game, empty if the game is matched by name.
+	ID string `json:",omitempty"`
+	// Name is the case-insensitive name of the deleted game, empty if the game is matched by identifier.
+	Name string `json:",omitempty"`
+	// Reason describes why the game was deleted.
+	Reason string `json:",omitempty"`
+}
+
+// matches checks if the tombstone marks the game as deleted.
+func (t *Tombstone) matches(game *Game) bool {
+	return (t.ID != "" && t.ID == game.key()) || (t.Name != "" && strings.EqualFold(t.Name, game.Name))
+}
+
+// MergeGames merges new games with existing games, keeping the earliest start of content.
+// Games marked by one of the tombstones are dropped.
+// It does not modify the given games.
+func MergeGames(newGames []*Game, existingGames []*Game, tombstones []*Tombstone) []*Game {
+	merged, _ := MergeGamesWithPolicy(newGames, existingGames, MergeEarliest, tombstones)
+
+	return merged
+}
+
+// MergeGamesWithPolicy merges new games with existing games and returns the conflicts that were resolved with the given policy, or the earliest start if empty.
+// Games are matched by identifier, name or content link, and games marked by one of the tombstones are dropped. It does not modify the given games.
+func MergeGamesWithPolicy(newGames []*Game, existingGames []*Game, policy MergePolicy, tombstones []*Tombstone) (merged []*Game, conflicts []*MergeConflict) {
+	return MergeGamesSeq(newGames, clonedGames(existingGames), policy, tombstones)
+}
+
+// MergeGamesSeq merges new games with existing games like "MergeGamesWithPolicy", but merges the existing games one at a time as they are iterated, e.g. while reading them.
+// It takes ownership of the existing games, which are merged without copying them and may be modified, but it does not modify the new games.
+func MergeGamesSeq(newGames []*Game, existingGames iter.Seq[*Game], policy MergePolicy, tombstones []*Tombstone) (merged []*Game, conflicts []*MergeConflict) {
+	if policy == "" {
+		policy = MergeEarliest
+	}
+	m := &merger{
+		policy: policy,
+	}
+
+	indexForKey := map[string]int{}
+	for game := range concatGames(clonedGames(newGames), existingGames) {
+		if slices.ContainsFunc(tombstones, func(t *Tombstone) bool { return t.matches(game) }) {
+			continue
+		}
+
+		keys := game.matchKeys()
+
+		i := -1
+		for _, key := range keys {
+			if j, ok := indexForKey[key]; ok {
+				i = j
+
+				break
 			}
+		}
+		if i < 0 {
+			merged = append(merged, game)
+			i = len(merged) - 1
+		} else {
+			m.mergeGame(merged[i], game) // The first game is the newer one and kept.
+		}
 
-			if b.Start.Before(a.Start) {
-				a.Start = b.Start
-				a.Link = b.Link
+		for _, key := range slices.Concat(merged[i].matchKeys(), keys) {
+			if _, ok := indexForKey[key]; !ok {
+				indexForKey[key] = i
 			}
+		}
+	}
+	slices.SortStableFunc(merged, func(a *Game, b *Game) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
 
-			return true
-		})
+	return merged, m.conflicts
+}
 
-		return true
-	})
+// clonedGames returns an iterator over copies of the games.
+func clonedGames(games []*Game) iter.Seq[*Game] {
+	return func(yield func(*Game) bool) {
+		for _, game := range games {
+			if !yield(game.clone()) {
+				return
+			}
+		}
+	}
+}
+
+// concatGames returns an iterator over the games of all iterators in order.
+func concatGames(seqs ...iter.Seq[*Game]) iter.Seq[*Game] {
+	return func(yield func(*Game) bool) {
+		for _, seq := range seqs {
+			for game := range seq {
+				if !yield(game) {
+					return
+				}
+			}
+		}
+	}
 }
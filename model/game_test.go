@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -11,15 +12,16 @@ func TestMergeGames(t *testing.T) {
 	type testCase struct {
 		Name string
 
-		A []*Game
-		B []*Game
+		A          []*Game
+		B          []*Game
+		Tombstones []*Tombstone
 
 		Expected []*Game
 	}
 
 	validate := func(t *testing.T, tc *testCase) {
 		t.Run(tc.Name, func(t *testing.T) {
-			assert.Equal(t, tc.Expected, MergeGames(tc.A, tc.B))
+			assert.Equal(t, tc.Expected, MergeGames(tc.A, tc.B, tc.Tombstones))
 		})
 	}
 
@@ -131,4 +133,1256 @@ func TestMergeGames(t *testing.T) {
 			},
 		})
 	})
+	validate(t, &testCase{
+		Name: "Capitalization",
+
+		A: []*Game{
+			&Game{
+				Name: "GTA V",
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "Gta V",
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "GTA V",
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Confidence",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+			},
+			&Game{
+				Name:       "bar",
+				Confidence: 0.5,
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name:       "foo",
+				Confidence: 0.9,
+			},
+			&Game{
+				Name:       "bar",
+				Confidence: 0.9,
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name:       "bar",
+				Confidence: 0.5,
+			},
+			&Game{
+				Name:       "foo",
+				Confidence: 0.9,
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Steam",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Steam: &SteamMetadata{
+					AppID: "1234",
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Steam: &SteamMetadata{
+					AppID: "1234",
+				},
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Episodes",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "B",
+						Start:    time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2020, 10, 11, 0, 0, 0, 0, time.UTC),
+						Episodes: 3,
+						Duration: Duration(3 * time.Hour),
+						VideoIDs: []string{"B", "C", "D"},
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						Duration: Duration(time.Hour),
+						VideoIDs: []string{"A"},
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2020, 10, 11, 0, 0, 0, 0, time.UTC),
+						Episodes: 4,
+						Duration: Duration(4 * time.Hour),
+						VideoIDs: []string{"B", "C", "D", "A"},
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Shared Episodes",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+						Episodes: 2,
+						Duration: Duration(2 * time.Hour),
+						VideoIDs: []string{"A", "B"},
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "B",
+						Start:    time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC),
+						Episodes: 2,
+						Duration: Duration(3 * time.Hour),
+						VideoIDs: []string{"B", "C"},
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC),
+						Episodes: 3,
+						Duration: Duration(3 * time.Hour),
+						VideoIDs: []string{"A", "B", "C"},
+					},
+				},
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Participants",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:       SourceYouTube,
+						Link:         "A",
+						Start:        time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						CoOp:         true,
+						Participants: []string{"Tobinator"},
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:       SourceYouTube,
+						Link:         "B",
+						Start:        time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+						CoOp:         true,
+						Participants: []string{"Tobinator", "Sarazar"},
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:       SourceYouTube,
+						Link:         "A",
+						Start:        time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						CoOp:         true,
+						Participants: []string{"Sarazar", "Tobinator"},
+					},
+				},
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Kinds",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "B",
+						Start:    time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+						VideoIDs: []string{"B"},
+						Episodes: 1,
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsTest,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						VideoIDs: []string{"A"},
+						Episodes: 1,
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "C",
+						Start:    time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC),
+						VideoIDs: []string{"C"},
+						Episodes: 1,
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "B",
+						Start:    time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+						VideoIDs: []string{"B", "C"},
+						Episodes: 2,
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsTest,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						VideoIDs: []string{"A"},
+						Episodes: 1,
+					},
+				},
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Legacy Content",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "C",
+						Start:    time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC),
+						VideoIDs: []string{"C"},
+						Episodes: 1,
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						VideoIDs: []string{"C"},
+						Episodes: 1,
+					},
+				},
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Completed",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:      SourceYouTube,
+						Link:        "B",
+						Start:       time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+						Completed:   true,
+						CompletedAt: time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:      SourceYouTube,
+						Link:        "A",
+						Start:       time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						Completed:   true,
+						CompletedAt: time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+	})
+	validate(t, &testCase{
+		Name: "Runs",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "C",
+						Start:    time.Date(2022, 10, 8, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 1,
+						VideoIDs: []string{"C"},
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"A"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "B",
+						Start:    time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 1,
+						VideoIDs: []string{"B"},
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"A"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "B",
+						Start:    time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 2,
+						VideoIDs: []string{"C", "B"},
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Renumbered Runs",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2014, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"A"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "C",
+						Start:    time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 2,
+						VideoIDs: []string{"C", "D"},
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "C",
+						Start:    time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"C"},
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "A",
+						Start:    time.Date(2014, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"A"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Link:     "C",
+						Start:    time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 2,
+						VideoIDs: []string{"C", "D"},
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Incremental Runs",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "E",
+						Start:    time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"E"},
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "A",
+						Start:    time.Date(2014, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2014, 12, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 2,
+						VideoIDs: []string{"A", "B"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "C",
+						Start:    time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2023, 12, 8, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 2,
+						VideoIDs: []string{"C", "D"},
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "A",
+						Start:    time.Date(2014, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2014, 12, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 2,
+						VideoIDs: []string{"A", "B"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "C",
+						Start:    time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 3,
+						VideoIDs: []string{"E", "C", "D"},
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Incremental New Run",
+
+		A: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "E",
+						Start:    time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"E"},
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "A",
+						Start:    time.Date(2014, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"A"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "C",
+						Start:    time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 1,
+						VideoIDs: []string{"C"},
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name: "foo",
+				Content: []*Content{
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "A",
+						Start:    time.Date(2014, 10, 8, 0, 0, 0, 0, time.UTC),
+						Episodes: 1,
+						VideoIDs: []string{"A"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "C",
+						Start:    time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+						Run:      1,
+						Episodes: 1,
+						VideoIDs: []string{"C"},
+					},
+					&Content{
+						Source:   SourceYouTube,
+						Kind:     KindLetsPlay,
+						Link:     "E",
+						Start:    time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC),
+						Run:      2,
+						Episodes: 1,
+						VideoIDs: []string{"E"},
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Renamed by Identifier",
+
+		A: []*Game{
+			&Game{
+				ID:   "steam-2677660",
+				Name: "Indiana Jones and the Great Circle",
+			},
+		},
+		B: []*Game{
+			&Game{
+				ID:            "steam-2677660",
+				Name:          "Indiana Jones",
+				PreviousNames: []string{"INDIANA JONES AND THE GREAT CIRCLE DEUTSCH"},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				ID:            "steam-2677660",
+				Name:          "Indiana Jones and the Great Circle",
+				PreviousNames: []string{"INDIANA JONES AND THE GREAT CIRCLE DEUTSCH", "Indiana Jones"},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Renamed by Content",
+
+		A: []*Game{
+			&Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		B: []*Game{
+			&Game{
+				ID:   "let-s-play-minecraft",
+				Name: "Let's Play Minecraft",
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				ID:            "let-s-play-minecraft",
+				Name:          "Minecraft",
+				PreviousNames: []string{"Let's Play Minecraft"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Tombstones",
+
+		A: []*Game{
+			&Game{
+				ID:   "im-anmarsch",
+				Name: "im Anmarsch",
+			},
+			&Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+			},
+		},
+		B: []*Game{
+			&Game{
+				ID:   "steam-2677660",
+				Name: "Indiana Jones and the Great Circle",
+			},
+			&Game{
+				ID:   "um-mitternacht",
+				Name: "UM MITTERNACHT",
+			},
+		},
+		Tombstones: []*Tombstone{
+			&Tombstone{
+				ID: "im-anmarsch",
+			},
+			&Tombstone{
+				ID: "steam-2677660",
+			},
+			&Tombstone{
+				Name:   "um Mitternacht",
+				Reason: "Episode title",
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+			},
+		},
+	})
+}
+
+func TestMergeGamesWithPolicy(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Policy   MergePolicy
+		New      []*Game
+		Existing []*Game
+
+		Expected          []*Game
+		ExpectedConflicts []*MergeConflict
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			newGames := make([]*Game, len(tc.New))
+			for i, game := range tc.New {
+				newGames[i] = game.clone()
+			}
+			existingGames := make([]*Game, len(tc.Existing))
+			for i, game := range tc.Existing {
+				existingGames[i] = game.clone()
+			}
+
+			actual, actualConflicts := MergeGamesWithPolicy(tc.New, tc.Existing, tc.Policy, nil)
+			assert.Equal(t, tc.Expected, actual)
+			assert.Equal(t, tc.ExpectedConflicts, actualConflicts)
+
+			// The given games are not modified.
+			assert.Equal(t, newGames, tc.New)
+			assert.Equal(t, existingGames, tc.Existing)
+
+			// Merging existing games one at a time while taking ownership of them gives the same result.
+			actual, actualConflicts = MergeGamesSeq(tc.New, slices.Values(existingGames), tc.Policy, nil)
+			assert.Equal(t, tc.Expected, actual)
+			assert.Equal(t, tc.ExpectedConflicts, actualConflicts)
+			assert.Equal(t, newGames, tc.New)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Prefer Existing",
+
+		Policy: MergePreferExisting,
+		New: []*Game{
+			&Game{
+				Name:  "GTA V",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		Existing: []*Game{
+			&Game{
+				Name:  "Gta V",
+				Steam: &SteamMetadata{AppID: "2"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "B",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name:  "Gta V",
+				Steam: &SteamMetadata{AppID: "2"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "B",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		ExpectedConflicts: []*MergeConflict{
+			&MergeConflict{
+				Game:     "GTA V",
+				Field:    "Name",
+				New:      "GTA V",
+				Existing: "Gta V",
+				Resolved: "Gta V",
+			},
+			&MergeConflict{
+				Game:     "Gta V",
+				Field:    "Steam",
+				New:      "1",
+				Existing: "2",
+				Resolved: "2",
+			},
+			&MergeConflict{
+				Game:     "Gta V",
+				Field:    "Content.Start",
+				Content:  "A",
+				New:      "2020-10-09T00:00:00Z",
+				Existing: "2020-10-08T00:00:00Z",
+				Resolved: "2020-10-08T00:00:00Z",
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Prefer Existing Rename",
+
+		Policy: MergePreferExisting,
+		New: []*Game{
+			&Game{
+				ID:   "minecraft",
+				Name: "Minecraft Deutsch",
+			},
+		},
+		Existing: []*Game{
+			&Game{
+				ID:            "minecraft",
+				Name:          "Minecraft",
+				PreviousNames: []string{"Let's Play Minecraft"},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				ID:            "minecraft",
+				Name:          "Minecraft",
+				PreviousNames: []string{"Let's Play Minecraft"},
+			},
+		},
+		ExpectedConflicts: []*MergeConflict{
+			&MergeConflict{
+				Game:     "Minecraft Deutsch",
+				Field:    "Name",
+				New:      "Minecraft Deutsch",
+				Existing: "Minecraft",
+				Resolved: "Minecraft",
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Prefer New",
+
+		Policy: MergePreferNew,
+		New: []*Game{
+			&Game{
+				Name:  "GTA V",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		Existing: []*Game{
+			&Game{
+				Name:  "Gta V",
+				Steam: &SteamMetadata{AppID: "2"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "B",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name:  "GTA V",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		ExpectedConflicts: []*MergeConflict{
+			&MergeConflict{
+				Game:     "GTA V",
+				Field:    "Name",
+				New:      "GTA V",
+				Existing: "Gta V",
+				Resolved: "GTA V",
+			},
+			&MergeConflict{
+				Game:     "GTA V",
+				Field:    "Steam",
+				New:      "1",
+				Existing: "2",
+				Resolved: "1",
+			},
+			&MergeConflict{
+				Game:     "GTA V",
+				Field:    "Content.Start",
+				Content:  "A",
+				New:      "2020-10-09T00:00:00Z",
+				Existing: "2020-10-08T00:00:00Z",
+				Resolved: "2020-10-09T00:00:00Z",
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Earliest",
+
+		Policy: MergeEarliest,
+		New: []*Game{
+			&Game{
+				Name:  "GTA V",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		Existing: []*Game{
+			&Game{
+				Name:  "Gta V",
+				Steam: &SteamMetadata{AppID: "2"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "B",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name:  "GTA V",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "B",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		ExpectedConflicts: []*MergeConflict{
+			&MergeConflict{
+				Game:     "GTA V",
+				Field:    "Name",
+				New:      "GTA V",
+				Existing: "Gta V",
+				Resolved: "GTA V",
+			},
+			&MergeConflict{
+				Game:     "GTA V",
+				Field:    "Steam",
+				New:      "1",
+				Existing: "2",
+				Resolved: "1",
+			},
+			&MergeConflict{
+				Game:     "GTA V",
+				Field:    "Content.Start",
+				Content:  "A",
+				New:      "2020-10-09T00:00:00Z",
+				Existing: "2020-10-08T00:00:00Z",
+				Resolved: "2020-10-08T00:00:00Z",
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Kind",
+
+		Policy: MergePreferExisting,
+		New: []*Game{
+			&Game{
+				Name:  "foo",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		Existing: []*Game{
+			&Game{
+				Name:  "foo",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsTest,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name:  "foo",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsTest,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		ExpectedConflicts: []*MergeConflict{
+			&MergeConflict{
+				Game:     "foo",
+				Field:    "Content.Kind",
+				Content:  "A",
+				New:      "letsplay",
+				Existing: "letstest",
+				Resolved: "letstest",
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "No Conflicts",
+
+		New: []*Game{
+			&Game{
+				Name:  "foo",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		Existing: []*Game{
+			&Game{
+				Name:  "foo",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		Expected: []*Game{
+			&Game{
+				Name:  "foo",
+				Steam: &SteamMetadata{AppID: "1"},
+				Content: []*Content{
+					&Content{
+						Source: SourceYouTube,
+						Kind:   KindLetsPlay,
+						Link:   "A",
+						Start:  time.Date(2020, 10, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestNewGameID(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		GameName string
+		Steam    *SteamMetadata
+
+		Expected string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, NewGameID(tc.GameName, tc.Steam))
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Slug",
+
+		GameName: "Indiana Jones and the Great Circle",
+
+		Expected: "indiana-jones-and-the-great-circle",
+	})
+
+	validate(t, &testCase{
+		Name: "Punctuation",
+
+		GameName: "Baldur's Gate 3: Director's Cut",
+
+		Expected: "baldur-s-gate-3-director-s-cut",
+	})
+
+	validate(t, &testCase{
+		Name: "Same Slug",
+
+		GameName: "Human: Fall Flat",
+
+		Expected: "human-fall-flat",
+	})
+
+	validate(t, &testCase{
+		Name: "Diacritics",
+
+		GameName: "Pokémon Karmesin & Purpur für Anfänger",
+
+		Expected: "pokemon-karmesin-purpur-fuer-anfaenger",
+	})
+
+	validate(t, &testCase{
+		Name: "Steam",
+
+		GameName: "Indiana Jones and the Great Circle",
+		Steam: &SteamMetadata{
+			AppID: "2677660",
+		},
+
+		Expected: "steam-2677660",
+	})
 }
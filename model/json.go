@@ -1,54 +1,418 @@
 package model
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"iter"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
-// JSONWrite writes game information in JSON format.
-func JSONWrite(writer io.Writer, games []*Game) error {
+// DataVersion is the schema version of the data file written by "JSONWrite".
+const DataVersion = 2
+
+// Data is the content of the data file.
+type Data struct {
+	// Version is the schema version of the data file.
+	Version int `json:"version"`
+	// GeneratedAt denotes when the data file was generated.
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Sources holds the sources of the videos the games were converted from, sorted by name.
+	Sources []SourceType `json:"sources"`
+	// Games holds the games sorted by name.
+	Games []*Game `json:"games"`
+}
+
+// dataMigration migrates a data file to the next schema version.
+type dataMigration struct {
+	// envelope migrates the raw fields of the envelope except its games, nil if they are unchanged.
+	envelope func(fields map[string]json.RawMessage) error
+	// game migrates the raw fields of a single game, nil if they are unchanged.
+	game func(fields map[string]json.RawMessage) error
+}
+
+// dataMigrations migrate a data file to the next schema version, indexed by the version they migrate from.
+var dataMigrations = map[int]dataMigration{
+	// Version 1 was a bare array of games, whose envelope has only a version before migrating.
+	1: {
+		envelope: func(fields map[string]json.RawMessage) error {
+			fields["sources"] = json.RawMessage(`[]`)
+
+			return nil
+		},
+	},
+}
+
+// migrateData migrates the raw fields of a data file except its games to the current schema version, and returns the schema version of the data file.
+func migrateData(fields map[string]json.RawMessage) (data *Data, version int, err error) {
+	if err := json.Unmarshal(fields["version"], &version); err != nil {
+		return nil, 0, errors.Wrap(err, "invalid data version")
+	} else if version < 1 || version > DataVersion {
+		return nil, 0, errors.Errorf("unsupported data version %d", version)
+	}
+	for v := version; v < DataVersion; v++ {
+		if migrate := dataMigrations[v].envelope; migrate != nil {
+			if err := migrate(fields); err != nil {
+				return nil, 0, errors.Wrapf(err, "failed to migrate data from version %d", v)
+			}
+		}
+	}
+	fields["version"] = json.RawMessage(strconv.Itoa(DataVersion))
+
+	migrated, err := json.Marshal(fields)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	data = &Data{}
+	if err := json.Unmarshal(migrated, data); err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	return data, version, nil
+}
+
+// migrateGame migrates the raw fields of a game of a data file with the given schema version to the current schema version.
+func migrateGame(version int, fields map[string]json.RawMessage, game *Game) error {
+	for ; version < DataVersion; version++ {
+		if migrate := dataMigrations[version].game; migrate != nil {
+			if err := migrate(fields); err != nil {
+				return errors.Wrapf(err, "failed to migrate game from version %d", version)
+			}
+		}
+	}
+
+	migrated, err := json.Marshal(fields)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(json.Unmarshal(migrated, game))
+}
+
+// envelopeFields holds the fields that "JSONWriteSeq" writes before the games.
+var envelopeFields = []string{"version", "generatedAt", "sources"}
+
+// JSONWrite writes game information in JSON format with the current schema version.
+func JSONWrite(writer io.Writer, data *Data) error {
 	// Sort for consistent output.
+	games := slices.Clone(data.Games)
 	slices.SortStableFunc(games, func(a, b *Game) int {
 		return strings.Compare(a.Name, b.Name)
 	})
-	for _, game := range games {
+
+	return JSONWriteSeq(writer, data, slices.Values(games))
+}
+
+// JSONWriteSeq writes game information in JSON format with the current schema version one game at a time, in the order of the iterator.
+// The games of the data are ignored.
+func JSONWriteSeq(writer io.Writer, data *Data, games iter.Seq[*Game]) error {
+	sources := slices.Sorted(slices.Values(data.Sources))
+	if sources == nil {
+		sources = []SourceType{}
+	}
+
+	// Write the envelope in the field order of "Data", with the games last so they can be streamed.
+	generatedAt, err := json.Marshal(data.GeneratedAt)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	encodedSources, err := json.MarshalIndent(sources, "  ", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	buffered := bufio.NewWriter(writer)
+	fmt.Fprintf(buffered, "{\n  \"version\": %d,\n  \"generatedAt\": %s,\n  \"sources\": %s,\n  \"games\": [", DataVersion, generatedAt, encodedSources)
+
+	empty := true
+	for game := range games {
+		// Sort for consistent output.
 		slices.SortStableFunc(game.Content, func(a, b *Content) int {
 			return strings.Compare(string(a.Source), string(b.Source))
 		})
+
+		encoded, err := json.MarshalIndent(game, "    ", "  ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !empty {
+			buffered.WriteString(",")
+		}
+		buffered.WriteString("\n    ")
+		buffered.Write(encoded)
+		empty = false
+	}
+	if !empty {
+		buffered.WriteString("\n  ")
+	}
+	buffered.WriteString("]\n}\n")
+
+	return errors.WithStack(buffered.Flush())
+}
+
+// JSONRead reads game information from JSON format.
+// Data files of earlier schema versions, including the bare array of games of the first version, are migrated to the current version.
+func JSONRead(reader io.Reader) (*Data, error) {
+	gameReader, err := NewJSONReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	data := gameReader.Data()
+	data.Games = slices.AppendSeq([]*Game{}, gameReader.Games())
+	if err := gameReader.Err(); err != nil {
+		return nil, err
+	}
+
+	// Sort for consistent output.
+	slices.SortStableFunc(data.Games, func(a, b *Game) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return data, nil
+}
+
+// JSONReader reads game information from JSON format one game at a time.
+type JSONReader struct {
+	// decoder decodes the games.
+	decoder *json.Decoder
+	// data holds the envelope of the data file without its games.
+	data *Data
+	// version holds the schema version of the data file before migrating.
+	version int
+	// migrateGames denotes if the games need to be migrated to the current schema version.
+	migrateGames bool
+	// envelope denotes if the end of an envelope follows the games in the decoder.
+	envelope bool
+	// done denotes if all games have been read.
+	done bool
+
+	err error
+}
+
+// NewJSONReader reads the envelope of a data file up to its games.
+// If the games follow all other fields as written by "JSONWrite", they are streamed one at a time, and otherwise held in their raw form until the rest of the envelope is read.
+// Data files of earlier schema versions, including the bare array of games of the first version, are migrated to the current version.
+func NewJSONReader(reader io.Reader) (*JSONReader, error) {
+	r := &JSONReader{
+		decoder: json.NewDecoder(reader),
+	}
+
+	token, err := r.decoder.Token()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fields := map[string]json.RawMessage{}
+	switch token {
+	case json.Delim('['):
+		fields["version"] = json.RawMessage(`1`)
+	case json.Delim('{'):
+		r.envelope = true
+		if err := r.readEnvelope(fields); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unexpected data %v", token)
+	}
+
+	if r.data, r.version, err = migrateData(fields); err != nil {
+		return nil, err
+	}
+	for version := r.version; version < DataVersion; version++ {
+		if dataMigrations[version].game != nil {
+			r.migrateGames = true
+		}
+	}
+
+	return r, nil
+}
+
+// readEnvelope reads the raw fields of the envelope up to the start of its games.
+// Games that do not follow all other fields are read in their raw form together with the rest of the envelope, and then decoded from there.
+func (r *JSONReader) readEnvelope(fields map[string]json.RawMessage) error {
+	var games json.RawMessage
+	for r.decoder.More() {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		key, _ := token.(string)
+		if key != "games" || !hasFields(fields, envelopeFields) {
+			var value json.RawMessage
+			if err := r.decoder.Decode(&value); err != nil {
+				return errors.WithStack(err)
+			}
+			if key == "games" {
+				games = value
+			} else {
+				fields[key] = value
+			}
+
+			continue
+		}
+
+		if token, err := r.decoder.Token(); err != nil {
+			return errors.WithStack(err)
+		} else if token == nil {
+			break
+		} else if token != json.Delim('[') {
+			return errors.Errorf("unexpected games %v", token)
+		}
+
+		return nil
+	}
+	if err := r.readEnd(); err != nil {
+		return err
+	}
+
+	if games == nil || bytes.Equal(games, []byte("null")) {
+		r.done = true
+
+		return nil
+	}
+	r.decoder = json.NewDecoder(bytes.NewReader(games))
+	r.envelope = false
+	if token, err := r.decoder.Token(); err != nil {
+		return errors.WithStack(err)
+	} else if token != json.Delim('[') {
+		return errors.Errorf("unexpected games %v", token)
+	}
+
+	return nil
+}
+
+// hasFields checks if all the given fields are set.
+func hasFields(fields map[string]json.RawMessage, names []string) bool {
+	for _, name := range names {
+		if _, ok := fields[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readEnd reads the end of the data file after its games.
+// Fields after the games are ignored, as all fields of the envelope are known to precede them, which leaves only unknown fields.
+func (r *JSONReader) readEnd() error {
+	if !r.envelope {
+		return nil
+	}
+	for r.decoder.More() {
+		if _, err := r.decoder.Token(); err != nil {
+			return errors.WithStack(err)
+		}
+		var value json.RawMessage
+		if err := r.decoder.Decode(&value); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if _, err := r.decoder.Token(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Data returns the envelope of the data file without its games.
+func (r *JSONReader) Data() *Data {
+	data := *r.data
+
+	return &data
+}
+
+// Games returns an iterator over the games in the order of the input, which can be iterated once.
+// The iteration stops at the first error, which is reported by "Err".
+func (r *JSONReader) Games() iter.Seq[*Game] {
+	return func(yield func(*Game) bool) {
+		if r.done {
+			return
+		}
+
+		for r.decoder.More() {
+			var game Game
+			if err := r.decodeGame(&game); err != nil {
+				r.err = err
+
+				return
+			}
+
+			// Sort for consistent output.
+			slices.SortStableFunc(game.Content, func(a, b *Content) int {
+				return strings.Compare(string(a.Source), string(b.Source))
+			})
+			if game.ID == "" { // Games written before identifiers were introduced.
+				game.ID = NewGameID(game.Name, game.Steam)
+			}
+
+			if !yield(&game) {
+				return
+			}
+		}
+
+		r.done = true
+		if _, err := r.decoder.Token(); err != nil {
+			r.err = errors.WithStack(err)
+		} else if err := r.readEnd(); err != nil {
+			r.err = err
+		}
+	}
+}
+
+// decodeGame decodes the next game and migrates it to the current schema version.
+func (r *JSONReader) decodeGame(game *Game) error {
+	if !r.migrateGames {
+		return errors.WithStack(r.decoder.Decode(game))
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := r.decoder.Decode(&fields); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return migrateGame(r.version, fields, game)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (r *JSONReader) Err() error {
+	return r.err
+}
+
+// MergeConflictsJSONWrite writes merge conflicts in JSON format.
+func MergeConflictsJSONWrite(writer io.Writer, conflicts []*MergeConflict) error {
+	if conflicts == nil {
+		conflicts = []*MergeConflict{}
 	}
 
 	encoder := json.NewEncoder(writer)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(games); err != nil {
+	if err := encoder.Encode(conflicts); err != nil {
 		return errors.WithStack(err)
 	}
 
 	return nil
 }
 
-// JSONRead reads game information from JSON format.
-func JSONRead(reader io.Reader) ([]*Game, error) {
-	var games []*Game
+// TombstonesJSONRead reads tombstones from JSON format.
+func TombstonesJSONRead(reader io.Reader) ([]*Tombstone, error) {
+	var tombstones []*Tombstone
 
 	decoder := json.NewDecoder(reader)
-	if err := decoder.Decode(&games); err != nil {
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&tombstones); err != nil {
 		return nil, errors.WithStack(err)
 	}
-
-	// Sort for consistent output.
-	slices.SortStableFunc(games, func(a, b *Game) int {
-		return strings.Compare(a.Name, b.Name)
-	})
-	for _, game := range games {
-		slices.SortStableFunc(game.Content, func(a, b *Content) int {
-			return strings.Compare(string(a.Source), string(b.Source))
-		})
+	for i, tombstone := range tombstones {
+		if tombstone.ID == "" && tombstone.Name == "" {
+			return nil, errors.Errorf("tombstone %d has neither an ID nor a name", i)
+		}
 	}
 
-	return games, nil
+	return tombstones, nil
 }
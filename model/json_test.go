@@ -2,6 +2,8 @@ package model
 
 import (
 	"bytes"
+	"encoding/json"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -22,9 +24,18 @@ func TestJSONWrite(t *testing.T) {
 	validate := func(t *testing.T, tc *testCase) {
 		t.Run(tc.Name, func(t *testing.T) {
 			var writer bytes.Buffer
-			err := JSONWrite(&writer, tc.Games)
+			err := JSONWrite(&writer, &Data{
+				GeneratedAt: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+				Sources:     []SourceType{SourceYouTube},
+				Games:       tc.Games,
+			})
 			require.NoError(t, err)
-			assert.JSONEq(t, tc.Expected, writer.String())
+			assert.JSONEq(t, `{
+				"version": 2,
+				"generatedAt": "2025-08-01T12:00:00Z",
+				"sources": ["youtube"],
+				"games": `+tc.Expected+`
+			}`, writer.String())
 		})
 	}
 
@@ -147,6 +158,86 @@ func TestJSONWrite(t *testing.T) {
 			}
 		]`,
 	})
+	validate(t, &testCase{
+		Name: "Steam Metadata",
+
+		Games: []*Game{
+			&Game{
+				Name: "Minecraft",
+				Content: []*Content{
+					&Content{
+						Source: SourceType("youtube"),
+						Link:   "some link",
+						Start:  time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Steam: &SteamMetadata{
+					AppID:       "1234",
+					Type:        "game",
+					Genres:      []string{"Action"},
+					ReleaseDate: time.Date(2011, 11, 18, 0, 0, 0, 0, time.UTC),
+					Platforms:   []string{"windows", "linux"},
+				},
+			},
+		},
+
+		Expected: `[
+			{
+				"Name": "Minecraft",
+				"Content": [
+					{
+						"Link": "some link",
+						"Start": "2025-07-26T00:00:00Z",
+						"Source": "youtube"
+					}
+				],
+				"Steam": {
+					"AppID": "1234",
+					"Type": "game",
+					"Genres": ["Action"],
+					"ReleaseDate": "2011-11-18T00:00:00Z",
+					"Platforms": ["windows", "linux"]
+				}
+			}
+		]`,
+	})
+	validate(t, &testCase{
+		Name: "Episodes",
+
+		Games: []*Game{
+			&Game{
+				Name: "Minecraft",
+				Content: []*Content{
+					&Content{
+						Source:   SourceType("youtube"),
+						Link:     "some link",
+						Start:    time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC),
+						End:      time.Date(2025, 7, 27, 0, 0, 0, 0, time.UTC),
+						Episodes: 2,
+						Duration: Duration(90 * time.Minute),
+						VideoIDs: []string{"a", "b"},
+					},
+				},
+			},
+		},
+
+		Expected: `[
+			{
+				"Name": "Minecraft",
+				"Content": [
+					{
+						"Link": "some link",
+						"Start": "2025-07-26T00:00:00Z",
+						"End": "2025-07-27T00:00:00Z",
+						"Episodes": 2,
+						"Duration": "1h30m0s",
+						"VideoIDs": ["a", "b"],
+						"Source": "youtube"
+					}
+				]
+			}
+		]`,
+	})
 }
 
 func TestJSONRead(t *testing.T) {
@@ -155,8 +246,9 @@ func TestJSONRead(t *testing.T) {
 
 		JSON string
 
-		Expected []*Game
-		Error    string
+		Expected        []*Game
+		ExpectedSources []SourceType
+		Error           string
 	}
 
 	validate := func(t *testing.T, tc *testCase) {
@@ -166,7 +258,12 @@ func TestJSONRead(t *testing.T) {
 				assert.ErrorContains(t, err, tc.Error)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, tc.Expected, actual)
+				assert.Equal(t, DataVersion, actual.Version)
+				if tc.ExpectedSources == nil { // Data files of the first version have no sources.
+					tc.ExpectedSources = []SourceType{}
+				}
+				assert.Equal(t, tc.ExpectedSources, actual.Sources)
+				assert.Equal(t, tc.Expected, actual.Games)
 			}
 		})
 	}
@@ -189,6 +286,7 @@ func TestJSONRead(t *testing.T) {
 
 		Expected: []*Game{
 			&Game{
+				ID:   "minecraft",
 				Name: "Minecraft",
 				Content: []*Content{
 					&Content{
@@ -229,6 +327,7 @@ func TestJSONRead(t *testing.T) {
 
 		Expected: []*Game{
 			&Game{
+				ID:   "adrift",
 				Name: "Adrift",
 				Content: []*Content{
 					&Content{
@@ -239,6 +338,7 @@ func TestJSONRead(t *testing.T) {
 				},
 			},
 			&Game{
+				ID:   "minecraft",
 				Name: "Minecraft",
 				Content: []*Content{
 					&Content{
@@ -274,6 +374,7 @@ func TestJSONRead(t *testing.T) {
 
 		Expected: []*Game{
 			&Game{
+				ID:   "minecraft",
 				Name: "Minecraft",
 				Content: []*Content{
 					&Content{
@@ -306,6 +407,333 @@ func TestJSONRead(t *testing.T) {
 
 		Expected: []*Game{},
 	})
+
+	validate(t, &testCase{
+		Name: "Identifier",
+
+		JSON: `[
+			{
+				"ID": "steam-322170",
+				"Name": "Geometry Dash",
+				"PreviousNames": ["Geometry Dash Lite"],
+				"Content": []
+			}
+		]`,
+
+		Expected: []*Game{
+			&Game{
+				ID:            "steam-322170",
+				Name:          "Geometry Dash",
+				PreviousNames: []string{"Geometry Dash Lite"},
+				Content:       []*Content{},
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Envelope",
+
+		JSON: `{
+			"version": 2,
+			"generatedAt": "2025-08-01T12:00:00Z",
+			"sources": ["youtube"],
+			"games": [
+				{
+					"ID": "minecraft",
+					"Name": "Minecraft",
+					"Content": [
+						{
+							"Link": "some link",
+							"Start": "2025-07-26T00:00:00Z",
+							"Source": "youtube"
+						}
+					]
+				}
+			]
+		}`,
+
+		Expected: []*Game{
+			&Game{
+				ID:   "minecraft",
+				Name: "Minecraft",
+				Content: []*Content{
+					&Content{
+						Source: SourceType("youtube"),
+						Link:   "some link",
+						Start:  time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		ExpectedSources: []SourceType{SourceYouTube},
+	})
+
+	validate(t, &testCase{
+		Name: "Unsupported Version",
+
+		JSON: `{"version": 3, "games": []}`,
+
+		Error: "unsupported data version 3",
+	})
+
+	validate(t, &testCase{
+		Name: "Missing Version",
+
+		JSON: `{"games": []}`,
+
+		Error: "invalid data version",
+	})
+}
+
+func TestJSONWriteSeq(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Games []*Game
+
+		Expected string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			var writer bytes.Buffer
+			err := JSONWriteSeq(&writer, &Data{
+				GeneratedAt: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+				Sources:     []SourceType{SourceYouTube},
+			}, slices.Values(tc.Games))
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, writer.String())
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "No Games",
+
+		Expected: `{
+  "version": 2,
+  "generatedAt": "2025-08-01T12:00:00Z",
+  "sources": [
+    "youtube"
+  ],
+  "games": []
+}
+`,
+	})
+
+	validate(t, &testCase{
+		Name: "Iterator Order",
+
+		Games: []*Game{
+			&Game{ID: "minecraft", Name: "Minecraft", Content: []*Content{}},
+			&Game{ID: "adrift", Name: "Adrift", Content: []*Content{}},
+		},
+
+		Expected: `{
+  "version": 2,
+  "generatedAt": "2025-08-01T12:00:00Z",
+  "sources": [
+    "youtube"
+  ],
+  "games": [
+    {
+      "ID": "minecraft",
+      "Name": "Minecraft",
+      "Content": []
+    },
+    {
+      "ID": "adrift",
+      "Name": "Adrift",
+      "Content": []
+    }
+  ]
+}
+`,
+	})
+}
+
+func TestJSONReader(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		JSON  string
+		Limit int
+		// Migrations replace the data migrations if set.
+		Migrations map[int]dataMigration
+
+		ExpectedData  *Data
+		ExpectedGames []string
+		Error         string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Migrations != nil {
+				migrations := dataMigrations
+				dataMigrations = tc.Migrations
+				defer func() {
+					dataMigrations = migrations
+				}()
+			}
+
+			reader, err := NewJSONReader(strings.NewReader(tc.JSON))
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedData, reader.Data())
+
+			var actualGames []string
+			for game := range reader.Games() {
+				actualGames = append(actualGames, game.ID)
+				if len(actualGames) == tc.Limit {
+					break
+				}
+			}
+			assert.Equal(t, tc.ExpectedGames, actualGames)
+			if tc.Error != "" {
+				assert.ErrorContains(t, reader.Err(), tc.Error)
+			} else {
+				assert.NoError(t, reader.Err())
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Envelope",
+
+		JSON: `{
+			"version": 2,
+			"generatedAt": "2025-08-01T12:00:00Z",
+			"sources": ["youtube"],
+			"games": [
+				{"ID": "minecraft", "Name": "Minecraft", "Content": []},
+				{"Name": "Adrift", "Content": []}
+			]
+		}`,
+
+		ExpectedData: &Data{
+			Version:     DataVersion,
+			GeneratedAt: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+			Sources:     []SourceType{SourceYouTube},
+		},
+		ExpectedGames: []string{"minecraft", "adrift"},
+	})
+
+	validate(t, &testCase{
+		Name: "Legacy Array",
+
+		JSON: `[{"Name": "Minecraft", "Content": []}]`,
+
+		ExpectedData: &Data{
+			Version: DataVersion,
+			Sources: []SourceType{},
+		},
+		ExpectedGames: []string{"minecraft"},
+	})
+
+	validate(t, &testCase{
+		Name: "Stop Early",
+
+		JSON:  `{"version": 2, "sources": [], "games": [{"Name": "Minecraft", "Content": []}, {"Name": "Adrift", "Content": []}]}`,
+		Limit: 1,
+
+		ExpectedData: &Data{
+			Version: DataVersion,
+			Sources: []SourceType{},
+		},
+		ExpectedGames: []string{"minecraft"},
+	})
+
+	validate(t, &testCase{
+		Name: "No Games",
+
+		JSON: `{"version": 2, "sources": []}`,
+
+		ExpectedData: &Data{
+			Version: DataVersion,
+			Sources: []SourceType{},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Sorted Fields",
+
+		JSON: `{
+			"games": [
+				{"Name": "Minecraft", "Content": []},
+				{"Name": "Adrift", "Content": []}
+			],
+			"generatedAt": "2025-08-01T12:00:00Z",
+			"sources": ["youtube"],
+			"version": 2
+		}`,
+
+		ExpectedData: &Data{
+			Version:     DataVersion,
+			GeneratedAt: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+			Sources:     []SourceType{SourceYouTube},
+		},
+		ExpectedGames: []string{"minecraft", "adrift"},
+	})
+
+	validate(t, &testCase{
+		Name: "Field After Games",
+
+		JSON: `{"version": 2, "sources": [], "games": [{"Name": "Minecraft", "Content": []}], "generatedAt": "2025-08-01T12:00:00Z"}`,
+
+		ExpectedData: &Data{
+			Version:     DataVersion,
+			GeneratedAt: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+			Sources:     []SourceType{},
+		},
+		ExpectedGames: []string{"minecraft"},
+	})
+
+	validate(t, &testCase{
+		Name: "Unknown Field After Games",
+
+		JSON: `{"version": 2, "generatedAt": "2025-08-01T12:00:00Z", "sources": [], "games": [{"Name": "Minecraft", "Content": []}], "comment": {"games": []}}`,
+
+		ExpectedData: &Data{
+			Version:     DataVersion,
+			GeneratedAt: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+			Sources:     []SourceType{},
+		},
+		ExpectedGames: []string{"minecraft"},
+	})
+
+	validate(t, &testCase{
+		Name: "Migrated Games",
+
+		JSON: `[{"Title": "Minecraft", "Content": []}]`,
+		Migrations: map[int]dataMigration{
+			1: {
+				envelope: dataMigrations[1].envelope,
+				game: func(fields map[string]json.RawMessage) error {
+					fields["Name"] = fields["Title"]
+					delete(fields, "Title")
+
+					return nil
+				},
+			},
+		},
+
+		ExpectedData: &Data{
+			Version: DataVersion,
+			Sources: []SourceType{},
+		},
+		ExpectedGames: []string{"minecraft"},
+	})
+
+	validate(t, &testCase{
+		Name: "Invalid Game",
+
+		JSON: `{"version": 2, "sources": [], "games": [{"Name": "Minecraft", "Content": []}, {"Name": 1}]}`,
+
+		ExpectedData: &Data{
+			Version: DataVersion,
+			Sources: []SourceType{},
+		},
+		ExpectedGames: []string{"minecraft"},
+		Error:         "cannot unmarshal number",
+	})
 }
 
 func TestVideoCSVWrite(t *testing.T) {
@@ -347,8 +775,8 @@ func TestVideoCSVWrite(t *testing.T) {
 		},
 
 		Expected: []string{
-			"Link,PublishedAt,Title,Description,ChannelID,VideoID,Source",
-			"https://www.youtube.com/watch?v=dQw4w9WgXcQ,2009-10-25T09:57:33Z,Never Gonna Give You Up,Rick Astley's official music video,UCuAXFkgsw1L7xaCfnd5JJOw,dQw4w9WgXcQ,youtube",
+			"Link,PublishedAt,Title,Description,Duration,Short,Playlist,ChannelID,VideoID,Source",
+			"https://www.youtube.com/watch?v=dQw4w9WgXcQ,2009-10-25T09:57:33Z,Never Gonna Give You Up,Rick Astley's official music video,,,,UCuAXFkgsw1L7xaCfnd5JJOw,dQw4w9WgXcQ,youtube",
 		},
 	})
 
@@ -377,9 +805,9 @@ func TestVideoCSVWrite(t *testing.T) {
 		},
 
 		Expected: []string{
-			"Link,PublishedAt,Title,Description,ChannelID,VideoID,Source",
-			"https://www.youtube.com/watch?v=abc123,2023-01-01T12:00:00Z,Test Video,A test video description,UCtest123,abc123,youtube",
-			"https://www.youtube.com/watch?v=dQw4w9WgXcQ,2009-10-25T09:57:33Z,Never Gonna Give You Up,Rick Astley's official music video,UCuAXFkgsw1L7xaCfnd5JJOw,dQw4w9WgXcQ,youtube",
+			"Link,PublishedAt,Title,Description,Duration,Short,Playlist,ChannelID,VideoID,Source",
+			"https://www.youtube.com/watch?v=abc123,2023-01-01T12:00:00Z,Test Video,A test video description,,,,UCtest123,abc123,youtube",
+			"https://www.youtube.com/watch?v=dQw4w9WgXcQ,2009-10-25T09:57:33Z,Never Gonna Give You Up,Rick Astley's official music video,,,,UCuAXFkgsw1L7xaCfnd5JJOw,dQw4w9WgXcQ,youtube",
 		},
 	})
 
@@ -387,7 +815,7 @@ func TestVideoCSVWrite(t *testing.T) {
 		Name:   "Empty Videos",
 		Videos: []*Video{},
 		Expected: []string{
-			"Link,PublishedAt,Title,Description,ChannelID,VideoID,Source",
+			"Link,PublishedAt,Title,Description,Duration,Short,Playlist,ChannelID,VideoID,Source",
 		},
 	})
 
@@ -407,8 +835,8 @@ func TestVideoCSVWrite(t *testing.T) {
 		},
 
 		Expected: []string{
-			"Link,PublishedAt,Title,Description,ChannelID,VideoID,Source",
-			"https://www.youtube.com/watch?v=test123,2023-01-01T00:00:00Z,,,,test123,youtube",
+			"Link,PublishedAt,Title,Description,Duration,Short,Playlist,ChannelID,VideoID,Source",
+			"https://www.youtube.com/watch?v=test123,2023-01-01T00:00:00Z,,,,,,,test123,youtube",
 		},
 	})
 }
@@ -487,6 +915,73 @@ func TestVideoCSVRead(t *testing.T) {
 		},
 	})
 
+	validate(t, &testCase{
+		Name: "Duration",
+
+		CSV: []string{
+			"Link,PublishedAt,Title,Description,Duration,ChannelID,VideoID,Source",
+			"https://www.youtube.com/watch?v=abc123,2023-01-01T12:00:00Z,Test Video,A test video description,3m33s,UCtest123,abc123,youtube",
+		},
+
+		Expected: []*Video{
+			{
+				VideoID:     "abc123",
+				Title:       "Test Video",
+				Description: "A test video description",
+				Duration:    Duration(3*time.Minute + 33*time.Second),
+				Link:        "https://www.youtube.com/watch?v=abc123",
+				PublishedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				ChannelID:   "UCtest123",
+				Source:      SourceYouTube,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Playlist",
+
+		CSV: []string{
+			"Link,PublishedAt,Title,Description,Duration,Playlist,ChannelID,VideoID,Source",
+			"https://www.youtube.com/watch?v=abc123,2023-01-01T12:00:00Z,Test Video,A test video description,,PLtest123,UCtest123,abc123,youtube",
+		},
+
+		Expected: []*Video{
+			{
+				VideoID:     "abc123",
+				Title:       "Test Video",
+				Description: "A test video description",
+				Playlist:    "PLtest123",
+				Link:        "https://www.youtube.com/watch?v=abc123",
+				PublishedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				ChannelID:   "UCtest123",
+				Source:      SourceYouTube,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Short",
+
+		CSV: []string{
+			"Link,PublishedAt,Title,Description,Duration,Short,ChannelID,VideoID,Source",
+			"https://www.youtube.com/watch?v=abc123,2023-01-01T12:00:00Z,Test Video,A test video description,2m,true,UCtest123,abc123,youtube",
+		},
+
+		Expected: []*Video{
+			{
+				VideoID:     "abc123",
+				Title:       "Test Video",
+				Description: "A test video description",
+				Duration:    Duration(2 * time.Minute),
+				Short:       true,
+				Link:        "https://www.youtube.com/watch?v=abc123",
+				PublishedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				ChannelID:   "UCtest123",
+				Source:      SourceYouTube,
+			},
+		},
+	})
+
 	validate(t, &testCase{
 		Name: "Header Only",
 		CSV: []string{
@@ -516,3 +1011,149 @@ func TestVideoCSVRead(t *testing.T) {
 		},
 	})
 }
+
+func TestVideoCSVReader(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		CSV   []string
+		Limit int
+
+		ExpectedVideos []string
+		Error          string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			reader := NewVideoCSVReader(strings.NewReader(strings.Join(tc.CSV, "\n")))
+
+			var actualVideos []string
+			for video := range reader.Videos() {
+				actualVideos = append(actualVideos, video.VideoID)
+				if len(actualVideos) == tc.Limit {
+					break
+				}
+			}
+			assert.Equal(t, tc.ExpectedVideos, actualVideos)
+			if tc.Error != "" {
+				assert.ErrorContains(t, reader.Err(), tc.Error)
+			} else {
+				assert.NoError(t, reader.Err())
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Input Order",
+
+		CSV: []string{
+			"Title,VideoID",
+			"Second,b",
+			"First,a",
+		},
+
+		ExpectedVideos: []string{"b", "a"},
+	})
+
+	validate(t, &testCase{
+		Name: "Stop Early",
+
+		CSV: []string{
+			"Title,VideoID",
+			"Second,b",
+			"First,a",
+		},
+		Limit: 1,
+
+		ExpectedVideos: []string{"b"},
+	})
+
+	validate(t, &testCase{
+		Name: "Empty",
+	})
+
+	validate(t, &testCase{
+		Name: "Invalid Video",
+
+		CSV: []string{
+			"Title,VideoID,PublishedAt",
+			"First,a,2025-07-26T00:00:00Z",
+			"Second,b,yesterday",
+		},
+
+		ExpectedVideos: []string{"a"},
+		Error:          "cannot parse",
+	})
+}
+
+func TestVideoCSVWriteSeq(t *testing.T) {
+	var writer bytes.Buffer
+	require.NoError(t, VideoCSVWriteSeq(&writer, slices.Values([]*Video{
+		&Video{VideoID: "b", Title: "Second"},
+		&Video{VideoID: "a", Title: "First"},
+	})))
+
+	assert.Equal(t, strings.Join([]string{
+		"Link,PublishedAt,Title,Description,Duration,Short,Playlist,ChannelID,VideoID,Source",
+		",0001-01-01T00:00:00Z,Second,,,,,,b,",
+		",0001-01-01T00:00:00Z,First,,,,,,a,",
+	}, "\n")+"\n", writer.String())
+}
+
+func TestTombstonesJSONRead(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		JSON string
+
+		Expected []*Tombstone
+		Error    string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := TombstonesJSONRead(strings.NewReader(tc.JSON))
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.Expected, actual)
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Tombstones",
+
+		JSON: `[
+			{"ID": "im-anmarsch"},
+			{"Name": "um Mitternacht", "Reason": "Episode title"}
+		]`,
+
+		Expected: []*Tombstone{
+			&Tombstone{
+				ID: "im-anmarsch",
+			},
+			&Tombstone{
+				Name:   "um Mitternacht",
+				Reason: "Episode title",
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Empty Tombstone",
+
+		JSON: `[{"Reason": "Episode title"}]`,
+
+		Error: "tombstone 0 has neither an ID nor a name",
+	})
+
+	validate(t, &testCase{
+		Name: "Unknown Field",
+
+		JSON: `[{"Title": "um Mitternacht"}]`,
+
+		Error: "unknown field",
+	})
+}
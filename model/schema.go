@@ -0,0 +1,153 @@
+package model
+
+import (
+	"encoding"
+	"encoding/json"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SchemaID is the URL under which the JSON Schema of the data file is published.
+const SchemaID = "https://grnkdb.dev/data.schema.json"
+
+// Schema is the subset of a JSON Schema (draft 2020-12) that is needed to describe the data file.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type   string   `json:"type,omitempty"`
+	Format string   `json:"format,omitempty"`
+	Enum   []string `json:"enum,omitempty"`
+	Const  any      `json:"const,omitempty"`
+
+	Items *Schema `json:"items,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// DataSchema generates the JSON Schema of the data file from the model types.
+func DataSchema() *Schema {
+	g := &schemaGenerator{
+		defs: map[string]*Schema{},
+	}
+
+	schema := g.object(reflect.TypeFor[Data]())
+	schema.Schema = "https://json-schema.org/draft/2020-12/schema"
+	schema.ID = SchemaID
+	schema.Title = "GRNKdb"
+	schema.Description = "Games played by Gronkh and the content produced with them."
+	schema.Defs = g.defs
+	schema.Properties["version"].Const = DataVersion
+
+	return schema
+}
+
+// schemaGenerator generates JSON Schemas from Go types, with struct types as definitions.
+type schemaGenerator struct {
+	defs map[string]*Schema
+}
+
+// schema returns the schema of a type, referencing the definition of struct types.
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	switch t {
+	case reflect.TypeFor[time.Time]():
+		return &Schema{Type: "string", Format: "date-time"}
+	case reflect.TypeFor[SourceType]():
+		return &Schema{Type: "string", Enum: enumStrings(allSourceTypes)}
+	case reflect.TypeFor[ContentKind]():
+		return &Schema{Type: "string", Enum: enumStrings(allContentKinds)}
+	}
+	if t.Implements(reflect.TypeFor[encoding.TextMarshaler]()) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // Reserve the name for recursive types.
+			g.defs[t.Name()] = g.object(t)
+		}
+
+		return &Schema{Ref: "#/$defs/" + t.Name()}
+	default:
+		panic("unsupported schema type " + t.String())
+	}
+}
+
+// object returns the schema of a struct type following the field names of "encoding/json".
+func (g *schemaGenerator) object(t reflect.Type) *Schema {
+	additionalProperties := false
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: &additionalProperties,
+	}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = g.schema(field.Type)
+		if optional := slices.ContainsFunc(strings.Split(options, ","), func(option string) bool {
+			return option == "omitempty" || option == "omitzero"
+		}); !optional {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// enumStrings returns the string values of an enumeration.
+func enumStrings[S ~string](values []S) []string {
+	enum := make([]string, len(values))
+	for i, value := range values {
+		enum[i] = string(value)
+	}
+
+	return enum
+}
+
+// SchemaJSONWrite writes a JSON Schema in JSON format.
+func SchemaJSONWrite(writer io.Writer, schema *Schema) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(schema); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
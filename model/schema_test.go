@@ -0,0 +1,186 @@
+package model
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateDataSchema validates a JSON document against the data schema with a draft 2020-12 validator.
+func validateDataSchema(t *testing.T, reader io.Reader) error {
+	var schemaJSON bytes.Buffer
+	require.NoError(t, SchemaJSONWrite(&schemaJSON, DataSchema()))
+	schemaDocument, err := jsonschema.UnmarshalJSON(&schemaJSON)
+	require.NoError(t, err)
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	require.NoError(t, compiler.AddResource(SchemaID, schemaDocument))
+	schema, err := compiler.Compile(SchemaID)
+	require.NoError(t, err)
+
+	document, err := jsonschema.UnmarshalJSON(reader)
+	require.NoError(t, err)
+
+	return schema.Validate(document)
+}
+
+func TestDataSchemaValidate(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		JSON string
+
+		Errors []string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDataSchema(t, strings.NewReader(tc.JSON))
+			if len(tc.Errors) == 0 {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				for _, expected := range tc.Errors {
+					assert.ErrorContains(t, err, expected)
+				}
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Valid",
+
+		JSON: `{
+			"version": 2,
+			"generatedAt": "2025-08-01T12:00:00Z",
+			"sources": ["youtube"],
+			"games": [
+				{
+					"ID": "steam-322170",
+					"Name": "Geometry Dash",
+					"Content": [
+						{
+							"Link": "some link",
+							"Start": "2025-07-26T00:00:00Z",
+							"Duration": "1h2m3s",
+							"Episodes": 3,
+							"Source": "youtube",
+							"Kind": "letsplay"
+						}
+					],
+					"Steam": {
+						"AppID": "322170",
+						"Genres": ["Action"]
+					},
+					"Confidence": 0.9
+				}
+			]
+		}`,
+	})
+
+	validate(t, &testCase{
+		Name: "Legacy Array",
+
+		JSON: `[]`,
+
+		Errors: []string{"at '': got array, want object"},
+	})
+
+	validate(t, &testCase{
+		Name: "Version",
+
+		JSON: `{"version": 1, "generatedAt": "2025-08-01T12:00:00Z", "sources": [], "games": []}`,
+
+		Errors: []string{"at '/version': value must be 2"},
+	})
+
+	validate(t, &testCase{
+		Name: "Missing Property",
+
+		JSON: `{"version": 2, "generatedAt": "2025-08-01T12:00:00Z", "sources": [], "games": [{"Content": []}]}`,
+
+		Errors: []string{"at '/games/0': missing property 'Name'"},
+	})
+
+	validate(t, &testCase{
+		Name: "Unknown Property",
+
+		JSON: `{"version": 2, "generatedAt": "2025-08-01T12:00:00Z", "sources": [], "games": [{"Name": "Minecraft", "Content": [], "Rating": 5}]}`,
+
+		Errors: []string{"at '/games/0': additional properties 'Rating' not allowed"},
+	})
+
+	validate(t, &testCase{
+		Name: "Invalid Values",
+
+		JSON: `{
+			"version": 2,
+			"generatedAt": "yesterday",
+			"sources": ["twitch"],
+			"games": [
+				{
+					"Name": "Minecraft",
+					"Content": [
+						{"Link": "some link", "Start": "2025-07-26T00:00:00Z", "Source": "youtube", "Episodes": 1.5, "CoOp": "yes"}
+					]
+				}
+			]
+		}`,
+
+		Errors: []string{
+			"at '/generatedAt': 'yesterday' is not valid date-time",
+			"at '/sources/0': value must be 'youtube'",
+			"at '/games/0/Content/0/Episodes': got number, want integer",
+			"at '/games/0/Content/0/CoOp': got string, want boolean",
+		},
+	})
+}
+
+func TestDataSchemaJSONWrite(t *testing.T) {
+	var writer bytes.Buffer
+	require.NoError(t, JSONWrite(&writer, &Data{
+		GeneratedAt: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+		Sources:     []SourceType{SourceYouTube},
+		Games: []*Game{
+			&Game{
+				ID:            "steam-322170",
+				Name:          "Geometry Dash",
+				PreviousNames: []string{"Geometry Dash Lite"},
+				Content: []*Content{
+					&Content{
+						Link:            "some link",
+						Start:           time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC),
+						End:             time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC),
+						Episodes:        3,
+						Duration:        Duration(time.Hour),
+						VideoIDs:        []string{"a", "b", "c"},
+						MissingEpisodes: 1,
+						CoOp:            true,
+						Participants:    []string{"Pandorya"},
+						Completed:       true,
+						CompletedAt:     time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC),
+						Source:          SourceYouTube,
+						Kind:            KindLetsPlay,
+						Run:             1,
+					},
+				},
+				Steam: &SteamMetadata{
+					AppID:       "322170",
+					Type:        "game",
+					Genres:      []string{"Action"},
+					ReleaseDate: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				Confidence: 0.9,
+			},
+		},
+	}))
+
+	assert.NoError(t, validateDataSchema(t, &writer))
+}
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"cmp"
+	"regexp"
+	"time"
+)
 
 // Video represents a video from a content platform.
 type Video struct {
@@ -13,6 +17,13 @@ type Video struct {
 	Title string `csv:"Title"`
 	// Description is the video description.
 	Description string `csv:"Description"`
+	// Duration is the video duration, zero if unknown.
+	Duration Duration `csv:"Duration"`
+	// Short denotes if the video is a YouTube Short or a clip.
+	Short bool `csv:"Short,omitempty"`
+
+	// Playlist is the identifier of a playlist of the channel containing the video, empty if none.
+	Playlist string `csv:"Playlist"`
 
 	// ChannelID is the identifier of the channel/creator.
 	ChannelID string `csv:"ChannelID"`
@@ -20,4 +31,49 @@ type Video struct {
 	VideoID string `csv:"VideoID"`
 	// Source is the source platform of the video.
 	Source SourceType `csv:"Source"`
+
+	// Episode is the position of the video within its series as parsed from the title, zero if unknown.
+	Episode Episode `csv:"-"`
+	// EpisodeTitle is the title of the episode as split off from the title, empty if unknown.
+	// It is only used for conversion and explanations, and neither persisted in the CSV nor in the games data.
+	EpisodeTitle string `csv:"-"`
+}
+
+// ShortMaxDuration is the maximum duration of videos that are always considered clips.
+// It is the original duration limit of YouTube Shorts, while longer Shorts are only known as such if the scraper marks them.
+const ShortMaxDuration = time.Minute
+
+var shortsTagRE = regexp.MustCompile(`(?i)#shorts?\b`)
+
+// IsShort checks if the video is marked as short, tagged as short in its title or description, or at most as long as a clip.
+func (v *Video) IsShort() bool {
+	if v.Short {
+		return true
+	} else if shortsTagRE.MatchString(v.Title) || shortsTagRE.MatchString(v.Description) {
+		return true
+	}
+
+	return v.Duration > 0 && time.Duration(v.Duration) <= ShortMaxDuration
+}
+
+// Episode is the position of a video within a series.
+type Episode struct {
+	// Season is the season number, zero if unknown.
+	Season int `json:",omitempty"`
+	// Number is the episode number within the season, zero if unknown.
+	Number int `json:",omitempty"`
+}
+
+// IsZero checks if the episode is unknown.
+func (e Episode) IsZero() bool {
+	return e.Number == 0
+}
+
+// Compare orders episodes by season and number, treating an unknown season as the first season.
+func (e Episode) Compare(other Episode) int {
+	if c := cmp.Compare(max(e.Season, 1), max(other.Season, 1)); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(e.Number, other.Number)
 }
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoIsShort(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Video *Video
+
+		Expected bool
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.Video.IsShort())
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Regular",
+
+		Video: &Video{
+			Title:    "Let's Play Minecraft #001",
+			Duration: Duration(20 * time.Minute),
+		},
+
+		Expected: false,
+	})
+
+	validate(t, &testCase{
+		Name: "Unknown Duration",
+
+		Video: &Video{
+			Title: "Let's Play Minecraft #001",
+		},
+
+		Expected: false,
+	})
+
+	validate(t, &testCase{
+		Name: "Marked",
+
+		Video: &Video{
+			Title:    "Minecraft",
+			Duration: Duration(2 * time.Minute),
+			Short:    true,
+		},
+
+		Expected: true,
+	})
+
+	validate(t, &testCase{
+		Name: "Tag",
+
+		Video: &Video{
+			Title:       "Creeper! 💥",
+			Description: "Aus Let's Play Minecraft #001 #Shorts",
+			Duration:    Duration(2 * time.Minute),
+		},
+
+		Expected: true,
+	})
+
+	validate(t, &testCase{
+		Name: "Duration",
+
+		Video: &Video{
+			Title:    "Creeper!",
+			Duration: Duration(45 * time.Second),
+		},
+
+		Expected: true,
+	})
+}
@@ -3,10 +3,15 @@ package youtube
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/bauersimon/grnkdb/model"
 	"github.com/bauersimon/grnkdb/scraper"
+	"github.com/bauersimon/grnkdb/util"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/api/youtube/v3"
@@ -19,6 +24,11 @@ type Scraper struct {
 	pageLimit   uint
 	pageResults uint
 
+	// client checks video URLs without following redirects.
+	client *http.Client
+	// shortsURL is the URL prefix under which YouTube serves Shorts.
+	shortsURL string
+
 	logger *zap.Logger
 }
 
@@ -37,6 +47,9 @@ func NewScraper(logger *zap.Logger, apiKey string, pageLimit uint, pageResults u
 		pageLimit:   pageLimit,
 		pageResults: pageResults,
 
+		client:    newShortsClient(),
+		shortsURL: shortsURL,
+
 		logger: logger,
 	}, nil
 }
@@ -61,9 +74,223 @@ func (s *Scraper) Videos(channelID string) ([]*model.Video, error) {
 		videos = append(videos, video)
 	}
 
+	if err := s.addDurations(videos); err != nil {
+		s.logger.Warn("failed to retrieve video durations", zap.Error(err))
+	}
+	if err := s.addPlaylists(channelID, videos); err != nil {
+		s.logger.Warn("failed to retrieve video playlists", zap.Error(err))
+	}
+	s.markShorts(videos)
+
 	return videos, nil
 }
 
+// videosPerRequest is the maximum number of video IDs the YouTube API accepts per request.
+const videosPerRequest = 50
+
+// addDurations retrieves and sets the durations of videos.
+func (s *Scraper) addDurations(videos []*model.Video) error {
+	videoForID := make(map[string]*model.Video, len(videos))
+	for _, video := range videos {
+		videoForID[video.VideoID] = video
+	}
+
+	for batch := range util.Windowed(videos, videosPerRequest) {
+		ids := make([]string, len(batch))
+		for i, video := range batch {
+			ids[i] = video.VideoID
+		}
+
+		response, err := s.service.Videos.List([]string{"contentDetails"}).Id(ids...).MaxResults(videosPerRequest).Do()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		for _, item := range response.Items {
+			video := videoForID[item.Id]
+			if video == nil || item.ContentDetails == nil {
+				continue
+			}
+
+			duration, err := parseDuration(item.ContentDetails.Duration)
+			if err != nil {
+				s.logger.Warn("failed to parse video duration",
+					zap.String("videoId", item.Id),
+					zap.Error(err))
+				continue
+			}
+			video.Duration = model.Duration(duration)
+		}
+	}
+
+	return nil
+}
+
+// addPlaylists retrieves the playlists of the channel and sets the playlist of each video.
+// Videos contained in multiple playlists keep the first playlist.
+// Like the videos, the playlists and their items are only retrieved up to the page limit, and not at all once every video has a playlist.
+func (s *Scraper) addPlaylists(channelID string, videos []*model.Video) error {
+	videoForID := make(map[string]*model.Video, len(videos))
+	for _, video := range videos {
+		videoForID[video.VideoID] = video
+	}
+	missing := len(videoForID)
+
+	var playlistIDs []string
+	var nextPageToken string
+	for page := 1; missing > 0; page++ {
+		call := s.service.Playlists.List([]string{"id"}).
+			ChannelId(channelID).
+			MaxResults(videosPerRequest)
+		if nextPageToken != "" {
+			call = call.PageToken(nextPageToken)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, playlist := range response.Items {
+			playlistIDs = append(playlistIDs, playlist.Id)
+		}
+
+		nextPageToken = response.NextPageToken
+		if nextPageToken == "" || s.isPageLimit(page) {
+			break
+		}
+	}
+
+	for _, playlistID := range playlistIDs {
+		if missing == 0 {
+			break
+		}
+		s.logger.Debug("scraping playlist", zap.String("id", playlistID))
+
+		nextPageToken = ""
+		for page := 1; missing > 0; page++ {
+			call := s.service.PlaylistItems.List([]string{"contentDetails"}).
+				PlaylistId(playlistID).
+				MaxResults(videosPerRequest)
+			if nextPageToken != "" {
+				call = call.PageToken(nextPageToken)
+			}
+
+			response, err := call.Do()
+			if err != nil {
+				return errors.Wrapf(err, "error fetching items of playlist %q", playlistID)
+			}
+			for _, item := range response.Items {
+				if item.ContentDetails == nil {
+					continue
+				}
+				if video := videoForID[item.ContentDetails.VideoId]; video != nil && video.Playlist == "" {
+					video.Playlist = playlistID
+					missing--
+				}
+			}
+
+			nextPageToken = response.NextPageToken
+			if nextPageToken == "" || s.isPageLimit(page) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// isPageLimit checks if the page is the last one to retrieve.
+func (s *Scraper) isPageLimit(page int) bool {
+	return s.pageLimit != 0 && page >= int(s.pageLimit)
+}
+
+// shortsURL is the URL prefix under which YouTube serves Shorts.
+// Requesting a regular video under this prefix redirects to its watch page.
+const shortsURL = "https://www.youtube.com/shorts/"
+
+// shortMaxDuration is the maximum duration of a YouTube Short.
+// Unlike "model.ShortMaxDuration", below which every video is considered a clip, videos up to this duration are only Shorts if YouTube serves them as such.
+const shortMaxDuration = 3 * time.Minute
+
+// newShortsClient returns a client which does not follow redirects, so the Shorts URL check can detect them.
+func newShortsClient() *http.Client {
+	return &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout: 10 * time.Second,
+	}
+}
+
+// markShorts marks videos which are tagged or short enough to be clips, and checks the Shorts URL of all other videos which could be Shorts by their duration.
+func (s *Scraper) markShorts(videos []*model.Video) {
+	for _, video := range videos {
+		if video.IsShort() {
+			video.Short = true
+
+			continue
+		} else if video.Duration == 0 || time.Duration(video.Duration) > shortMaxDuration {
+			continue
+		}
+
+		short, err := s.isShort(video.VideoID)
+		if err != nil {
+			s.logger.Warn("failed to check if video is a short",
+				zap.String("videoId", video.VideoID),
+				zap.Error(err))
+			continue
+		}
+		video.Short = short
+	}
+}
+
+// isShort checks if YouTube serves the video as a Short.
+func (s *Scraper) isShort(videoID string) (bool, error) {
+	request, err := http.NewRequest(http.MethodHead, s.shortsURL+url.PathEscape(videoID), nil)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusOK:
+		return true, nil
+	case response.StatusCode >= 300 && response.StatusCode < 400:
+		return false, nil
+	default:
+		return false, errors.Errorf("unexpected status %q", response.Status)
+	}
+}
+
+var durationRE = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses an ISO 8601 duration as used by the YouTube API, e.g. "PT1H2M3S".
+func parseDuration(s string) (time.Duration, error) {
+	match := durationRE.FindStringSubmatch(s)
+	if match == nil {
+		return 0, errors.Errorf("invalid duration %q", s)
+	}
+
+	var duration time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if match[i+1] == "" {
+			continue
+		}
+		value, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		duration += time.Duration(value) * unit
+	}
+
+	return duration, nil
+}
+
 func convertPlaylistItemToVideo(item *youtube.PlaylistItem) (*model.Video, error) {
 	publishedAt, err := time.Parse(time.RFC3339, item.Snippet.PublishedAt)
 	if err != nil {
@@ -126,7 +353,7 @@ func (s *Scraper) scrapeChannel(id string) (videos []*youtube.PlaylistItem, err
 		nextPageToken = playlistResult.NextPageToken
 		if nextPageToken == "" {
 			break
-		} else if s.pageLimit != 0 && page > int(s.pageLimit)-1 {
+		} else if s.isPageLimit(page) {
 			break
 		}
 	}
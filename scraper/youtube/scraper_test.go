@@ -0,0 +1,279 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bauersimon/grnkdb/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/api/option"
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestParseDuration(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Duration string
+
+		Expected time.Duration
+		Error    string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := parseDuration(tc.Duration)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.Expected, actual)
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Full",
+
+		Duration: "PT1H2M3S",
+
+		Expected: time.Hour + 2*time.Minute + 3*time.Second,
+	})
+	validate(t, &testCase{
+		Name: "Minutes",
+
+		Duration: "PT25M",
+
+		Expected: 25 * time.Minute,
+	})
+	validate(t, &testCase{
+		Name: "Days",
+
+		Duration: "P1DT2S",
+
+		Expected: 24*time.Hour + 2*time.Second,
+	})
+	validate(t, &testCase{
+		Name: "Live",
+
+		Duration: "P0D",
+
+		Expected: 0,
+	})
+	validate(t, &testCase{
+		Name: "Invalid",
+
+		Duration: "1:02:03",
+
+		Error: "invalid duration",
+	})
+}
+
+func TestScraperMarkShorts(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Video *model.Video
+
+		ExpectedShort   bool
+		ExpectedChecked bool
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			var checked bool
+			// The stand-in serves the Shorts URL of "short" and redirects all other videos to their watch page.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				checked = true
+				if strings.TrimPrefix(r.URL.Path, "/shorts/") == "short" {
+					w.WriteHeader(http.StatusOK)
+				} else {
+					http.Redirect(w, r, "/watch?v="+strings.TrimPrefix(r.URL.Path, "/shorts/"), http.StatusSeeOther)
+				}
+			}))
+			defer server.Close()
+
+			scraper := &Scraper{
+				client:    newShortsClient(),
+				shortsURL: server.URL + "/shorts/",
+				logger:    zaptest.NewLogger(t),
+			}
+			scraper.markShorts([]*model.Video{tc.Video})
+
+			assert.Equal(t, tc.ExpectedShort, tc.Video.Short)
+			assert.Equal(t, tc.ExpectedChecked, checked)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Long Video",
+
+		Video: &model.Video{
+			VideoID:  "long",
+			Duration: model.Duration(20 * time.Minute),
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Clip",
+
+		Video: &model.Video{
+			VideoID:  "clip",
+			Duration: model.Duration(30 * time.Second),
+		},
+
+		ExpectedShort: true,
+	})
+
+	validate(t, &testCase{
+		Name: "Tag",
+
+		Video: &model.Video{
+			VideoID:  "tagged",
+			Title:    "Creeper! #shorts",
+			Duration: model.Duration(2 * time.Minute),
+		},
+
+		ExpectedShort: true,
+	})
+
+	validate(t, &testCase{
+		Name: "Short URL",
+
+		Video: &model.Video{
+			VideoID:  "short",
+			Duration: model.Duration(2 * time.Minute),
+		},
+
+		ExpectedShort:   true,
+		ExpectedChecked: true,
+	})
+
+	validate(t, &testCase{
+		Name: "Redirect",
+
+		Video: &model.Video{
+			VideoID:  "regular",
+			Duration: model.Duration(2 * time.Minute),
+		},
+
+		ExpectedChecked: true,
+	})
+}
+
+func TestScraperAddPlaylists(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		PageLimit uint
+		VideoIDs  []string
+
+		ExpectedPlaylists []string
+		ExpectedRequests  int
+	}
+
+	// pages holds the pages of the playlists of the channel and of the items of each playlist.
+	pages := map[string][][]string{
+		"playlists": {{"p1", "p2"}, {"p3"}},
+		"p1":        {{"a"}, {"b"}},
+		"p2":        {{"c"}},
+		"p3":        {{"d"}},
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			var requests int
+			// The stand-in serves the pages of the YouTube API with the page index as token.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				list := "playlists"
+				if strings.HasSuffix(r.URL.Path, "/playlistItems") {
+					list = r.URL.Query().Get("playlistId")
+				}
+				page := 0
+				if token := r.URL.Query().Get("pageToken"); token != "" {
+					page = int(token[0] - '0')
+				}
+
+				var items []string
+				for _, id := range pages[list][page] {
+					if list == "playlists" {
+						items = append(items, fmt.Sprintf(`{"id": %q}`, id))
+					} else {
+						items = append(items, fmt.Sprintf(`{"contentDetails": {"videoId": %q}}`, id))
+					}
+				}
+				var nextPageToken string
+				if page+1 < len(pages[list]) {
+					nextPageToken = strconv.Itoa(page + 1)
+				}
+				fmt.Fprintf(w, `{"items": [%s], "nextPageToken": %q}`, strings.Join(items, ","), nextPageToken)
+			}))
+			defer server.Close()
+
+			service, err := youtube.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithoutAuthentication())
+			require.NoError(t, err)
+			scraper := &Scraper{
+				service:   service,
+				pageLimit: tc.PageLimit,
+				logger:    zaptest.NewLogger(t),
+			}
+			videos := make([]*model.Video, len(tc.VideoIDs))
+			for i, videoID := range tc.VideoIDs {
+				videos[i] = &model.Video{VideoID: videoID}
+			}
+
+			require.NoError(t, scraper.addPlaylists("channel", videos))
+
+			actualPlaylists := make([]string, len(videos))
+			for i, video := range videos {
+				actualPlaylists[i] = video.Playlist
+			}
+			assert.Equal(t, tc.ExpectedPlaylists, actualPlaylists)
+			assert.Equal(t, tc.ExpectedRequests, requests)
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Unlimited",
+
+		VideoIDs: []string{"a", "b", "c", "d", "unknown"},
+
+		ExpectedPlaylists: []string{"p1", "p1", "p2", "p3", ""},
+		ExpectedRequests:  6,
+	})
+
+	validate(t, &testCase{
+		Name: "Page Limit",
+
+		PageLimit: 1,
+		VideoIDs:  []string{"a", "b", "c", "d"},
+
+		ExpectedPlaylists: []string{"p1", "", "p2", ""},
+		ExpectedRequests:  3,
+	})
+
+	validate(t, &testCase{
+		Name: "All Found",
+
+		VideoIDs: []string{"a"},
+
+		ExpectedPlaylists: []string{"p1"},
+		ExpectedRequests:  3,
+	})
+
+	validate(t, &testCase{
+		Name: "No Videos",
+
+		ExpectedPlaylists: []string{},
+	})
+}
@@ -5,10 +5,14 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/avast/retry-go"
+	"github.com/bauersimon/grnkdb/metadata"
+	"github.com/bauersimon/grnkdb/model"
 	"github.com/pkg/errors"
 )
 
@@ -17,6 +21,8 @@ type Client struct {
 	baseUrl string
 }
 
+var _ metadata.Provider = (*Client)(nil)
+
 // NewClient returns a new instance.
 func NewClient() *Client {
 	return &Client{
@@ -24,22 +30,174 @@ func NewClient() *Client {
 	}
 }
 
-var steamNameCache = map[string]string{}
+// AppDetails holds the store details of a Steam app.
+type AppDetails struct {
+	// AppID is the Steam AppID.
+	AppID string
+	// Name is the official name of the app.
+	Name string
+	// Type is the app type, e.g. "game" or "dlc".
+	Type string
+	// Genres holds the genre descriptions.
+	Genres []string
+	// Categories holds the category descriptions, e.g. "Single-player".
+	Categories []string
+	// ReleaseDate is the release date, zero if unknown or not yet released.
+	ReleaseDate time.Time
+	// Developers holds the developer names.
+	Developers []string
+	// Publishers holds the publisher names.
+	Publishers []string
+	// HeaderImage is the URL of the store header image.
+	HeaderImage string
+	// Platforms holds the supported platforms.
+	Platforms Platforms
+}
+
+// Platforms holds the platforms an app supports.
+type Platforms struct {
+	Windows bool `json:"windows"`
+	Mac     bool `json:"mac"`
+	Linux   bool `json:"linux"`
+}
+
+// Metadata converts the app details into game metadata.
+func (d *AppDetails) Metadata() *model.SteamMetadata {
+	metadata := &model.SteamMetadata{
+		AppID:       d.AppID,
+		Type:        d.Type,
+		Genres:      d.Genres,
+		Categories:  d.Categories,
+		ReleaseDate: d.ReleaseDate,
+		Developers:  d.Developers,
+		Publishers:  d.Publishers,
+		HeaderImage: d.HeaderImage,
+	}
+	if d.Platforms.Windows {
+		metadata.Platforms = append(metadata.Platforms, "windows")
+	}
+	if d.Platforms.Mac {
+		metadata.Platforms = append(metadata.Platforms, "mac")
+	}
+	if d.Platforms.Linux {
+		metadata.Platforms = append(metadata.Platforms, "linux")
+	}
+
+	return metadata
+}
+
+// releaseDateLayouts holds the date layouts the store API uses for release dates.
+var releaseDateLayouts = []string{
+	"2 Jan, 2006",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+	"Jan 2006",
+	"2006",
+}
+
+func parseReleaseDate(date string) time.Time {
+	for _, layout := range releaseDateLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(date)); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// appCache caches app details by AppID and is safe for concurrent use.
+// Concurrent lookups of the same AppID share a single request.
+type appCache struct {
+	lock sync.Mutex
+	apps map[string]*appCacheEntry
+}
+
+type appCacheEntry struct {
+	done    chan struct{}
+	details *AppDetails
+	err     error
+}
+
+func newAppCache() *appCache {
+	return &appCache{
+		apps: map[string]*appCacheEntry{},
+	}
+}
+
+// get returns the cached app details or fetches them. Failed lookups are not cached.
+func (c *appCache) get(appID string, fetch func() (*AppDetails, error)) (*AppDetails, error) {
+	c.lock.Lock()
+	if entry, ok := c.apps[appID]; ok {
+		c.lock.Unlock()
+		<-entry.done
+
+		return entry.details, entry.err
+	}
+	entry := &appCacheEntry{
+		done: make(chan struct{}),
+	}
+	c.apps[appID] = entry
+	c.lock.Unlock()
+
+	entry.details, entry.err = fetch()
+	if entry.err != nil {
+		c.lock.Lock()
+		delete(c.apps, appID)
+		c.lock.Unlock()
+	}
+	close(entry.done)
+
+	return entry.details, entry.err
+}
+
+var steamAppCache = newAppCache()
+
+var storeLinkRE = regexp.MustCompile(`steampowered\.com\/app\/(\d+)`)
+
+// Game resolves the game of the first Steam store link in the description.
+func (c *Client) Game(description string) (*metadata.Game, error) {
+	match := storeLinkRE.FindStringSubmatch(description)
+	if len(match) == 0 {
+		return nil, nil
+	}
+
+	details, err := c.AppDetails(match[1])
+	if err != nil {
+		return nil, err
+	}
+
+	steamMetadata := details.Metadata()
+
+	return &metadata.Game{
+		Name:  details.Name,
+		Link:  steamMetadata.StoreLink(),
+		Steam: steamMetadata,
+	}, nil
+}
 
 // GameName gets the name of a game via its AppID.
 func (c *Client) GameName(appID string) (game string, err error) {
-	if game = steamNameCache[appID]; game != "" {
-		return game, nil
+	details, err := c.AppDetails(appID)
+	if err != nil {
+		return "", err
 	}
-	defer func() {
-		if err == nil {
-			steamNameCache[appID] = game
-		}
-	}()
 
+	return details.Name, nil
+}
+
+// AppDetails gets the store details of an app via its AppID.
+// It is safe for concurrent use.
+func (c *Client) AppDetails(appID string) (details *AppDetails, err error) {
+	return steamAppCache.get(appID, func() (*AppDetails, error) {
+		return c.fetchAppDetails(appID)
+	})
+}
+
+// fetchAppDetails requests the store details of an app via its AppID.
+func (c *Client) fetchAppDetails(appID string) (details *AppDetails, err error) {
 	url, err := url.JoinPath(c.baseUrl, "appdetails")
 	if err != nil {
-		return "", errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
 	url += "?appids=" + appID
 
@@ -70,23 +228,57 @@ func (c *Client) GameName(appID string) (game string, err error) {
 			return err != nil && strings.Contains(err.Error(), "rate limit")
 		}),
 	); err != nil {
-		return "", errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
 
+	type description struct {
+		Description string `json:"description"`
+	}
 	var apiResponse map[string]struct {
 		Success bool `json:"success"`
 		Data    struct {
-			Name string `json:"name"`
+			Type        string        `json:"type"`
+			Name        string        `json:"name"`
+			Genres      []description `json:"genres"`
+			Categories  []description `json:"categories"`
+			ReleaseDate struct {
+				ComingSoon bool   `json:"coming_soon"`
+				Date       string `json:"date"`
+			} `json:"release_date"`
+			Developers  []string  `json:"developers"`
+			Publishers  []string  `json:"publishers"`
+			HeaderImage string    `json:"header_image"`
+			Platforms   Platforms `json:"platforms"`
 		} `json:"data"`
 	}
 
 	if err := json.Unmarshal(body, &apiResponse); err != nil {
-		return "", errors.WithStack(err)
+		return nil, errors.WithStack(err)
+	}
+
+	appData, exists := apiResponse[appID]
+	if !exists || !appData.Success {
+		return nil, errors.Errorf("unknown game ID %q", appID)
 	}
 
-	if appData, exists := apiResponse[appID]; exists && appData.Success {
-		return appData.Data.Name, nil
+	details = &AppDetails{
+		AppID:       appID,
+		Name:        appData.Data.Name,
+		Type:        appData.Data.Type,
+		Developers:  appData.Data.Developers,
+		Publishers:  appData.Data.Publishers,
+		HeaderImage: appData.Data.HeaderImage,
+		Platforms:   appData.Data.Platforms,
+	}
+	for _, genre := range appData.Data.Genres {
+		details.Genres = append(details.Genres, genre.Description)
+	}
+	for _, category := range appData.Data.Categories {
+		details.Categories = append(details.Categories, category.Description)
+	}
+	if !appData.Data.ReleaseDate.ComingSoon {
+		details.ReleaseDate = parseReleaseDate(appData.Data.ReleaseDate.Date)
 	}
 
-	return "", errors.Errorf("unknown game ID %q", appID)
+	return details, nil
 }
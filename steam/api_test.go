@@ -5,8 +5,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/bauersimon/grnkdb/metadata"
+	"github.com/bauersimon/grnkdb/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,7 +37,7 @@ func TestGameName(t *testing.T) {
 			t.Cleanup(func() {
 				server.CloseClientConnections()
 				server.Client()
-				steamNameCache = map[string]string{}
+				steamAppCache = newAppCache()
 			})
 
 			client := NewClient()
@@ -109,3 +114,162 @@ func TestGameName(t *testing.T) {
 		})
 	}
 }
+
+func TestAppDetails(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Response string
+		AppID    string
+
+		Expected *AppDetails
+		Error    string
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.AppID, r.URL.Query().Get("appids"))
+
+				_, _ = fmt.Fprintln(w, tc.Response)
+			}))
+			t.Cleanup(func() {
+				server.Close()
+				steamAppCache = newAppCache()
+			})
+
+			client := NewClient()
+			client.baseUrl = server.URL
+
+			actual, err := client.AppDetails(tc.AppID)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.Expected, actual)
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Full",
+
+		Response: `{"2677660":{"success":true,"data":{
+			"type":"game",
+			"name":"Indiana Jones and the Great Circle",
+			"steam_appid":2677660,
+			"header_image":"https://example.com/header.jpg",
+			"developers":["MachineGames"],
+			"publishers":["Bethesda Softworks"],
+			"platforms":{"windows":true,"mac":false,"linux":false},
+			"categories":[{"id":2,"description":"Single-player"}],
+			"genres":[{"id":"1","description":"Action"},{"id":"25","description":"Adventure"}],
+			"release_date":{"coming_soon":false,"date":"8 Dec, 2024"}
+		}}}`,
+		AppID: "2677660",
+
+		Expected: &AppDetails{
+			AppID:       "2677660",
+			Name:        "Indiana Jones and the Great Circle",
+			Type:        "game",
+			Genres:      []string{"Action", "Adventure"},
+			Categories:  []string{"Single-player"},
+			ReleaseDate: time.Date(2024, 12, 8, 0, 0, 0, 0, time.UTC),
+			Developers:  []string{"MachineGames"},
+			Publishers:  []string{"Bethesda Softworks"},
+			HeaderImage: "https://example.com/header.jpg",
+			Platforms: Platforms{
+				Windows: true,
+			},
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Coming Soon",
+
+		Response: `{"1234":{"success":true,"data":{"type":"game","name":"foo","release_date":{"coming_soon":true,"date":"Q4 2030"}}}}`,
+		AppID:    "1234",
+
+		Expected: &AppDetails{
+			AppID: "1234",
+			Name:  "foo",
+			Type:  "game",
+		},
+	})
+
+	validate(t, &testCase{
+		Name: "Unsuccessful",
+
+		Response: `{"1234":{"success":false}}`,
+		AppID:    "1234",
+
+		Error: "unknown game ID",
+	})
+}
+
+func TestGame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1234", r.URL.Query().Get("appids"))
+
+		_, _ = fmt.Fprintln(w, `{"1234":{"success":true,"data":{"type":"game","name":"foo","platforms":{"windows":true,"linux":true}}}}`)
+	}))
+	t.Cleanup(func() {
+		server.Close()
+		steamAppCache = newAppCache()
+	})
+
+	client := NewClient()
+	client.baseUrl = server.URL
+
+	t.Run("No Link", func(t *testing.T) {
+		actual, err := client.Game("no store link")
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("Link", func(t *testing.T) {
+		actual, err := client.Game("Spiel: https://store.steampowered.com/app/1234/foo/")
+		require.NoError(t, err)
+		assert.Equal(t, &metadata.Game{
+			Name: "foo",
+			Link: "https://store.steampowered.com/app/1234",
+			Steam: &model.SteamMetadata{
+				AppID:     "1234",
+				Type:      "game",
+				Platforms: []string{"windows", "linux"},
+			},
+		}, actual)
+	})
+}
+
+func TestAppDetailsConcurrent(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(10 * time.Millisecond) // Let concurrent lookups pile up.
+
+		_, _ = fmt.Fprintln(w, `{"1234":{"success":true,"data":{"type":"game","name":"foo"}}}`)
+	}))
+	t.Cleanup(func() {
+		server.Close()
+		steamAppCache = newAppCache()
+	})
+
+	client := NewClient()
+	client.baseUrl = server.URL
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			actual, err := client.AppDetails("1234")
+			assert.NoError(t, err)
+			assert.Equal(t, "foo", actual.Name)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), requests.Load())
+}
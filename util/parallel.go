@@ -0,0 +1,24 @@
+package util
+
+import "sync"
+
+// Parallel calls the function for each index in [0, count) using the given number of concurrent workers.
+func Parallel(count int, workers uint, do func(i int)) {
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(1, workers), uint(max(0, count))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indices {
+				do(i)
+			}
+		}()
+	}
+	for i := range count {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallel(t *testing.T) {
+	type testCase struct {
+		Name string
+
+		Count   int
+		Workers uint
+	}
+
+	validate := func(t *testing.T, tc *testCase) {
+		t.Run(tc.Name, func(t *testing.T) {
+			calls := make([]atomic.Int32, tc.Count)
+			Parallel(tc.Count, tc.Workers, func(i int) {
+				calls[i].Add(1)
+			})
+
+			for i := range calls {
+				assert.Equalf(t, int32(1), calls[i].Load(), "index %d", i)
+			}
+		})
+	}
+
+	validate(t, &testCase{
+		Name: "Empty",
+
+		Count:   0,
+		Workers: 4,
+	})
+	validate(t, &testCase{
+		Name: "Sequential",
+
+		Count:   10,
+		Workers: 1,
+	})
+	validate(t, &testCase{
+		Name: "Concurrent",
+
+		Count:   100,
+		Workers: 8,
+	})
+	validate(t, &testCase{
+		Name: "No Workers",
+
+		Count:   10,
+		Workers: 0,
+	})
+}